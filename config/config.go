@@ -7,6 +7,8 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
 
 	"golang.org/x/mod/semver"
 	yaml "gopkg.in/yaml.v2"
@@ -15,6 +17,7 @@ import (
 	"github.com/zexot-com/commitlint/internal"
 	"github.com/zexot-com/commitlint/internal/registry"
 	"github.com/zexot-com/commitlint/lint"
+	"github.com/zexot-com/commitlint/rule"
 )
 
 // Parse parse given file in confPath, and return Config instance, error if any
@@ -25,33 +28,119 @@ func Parse(confPath string) (*lint.Config, error) {
 		return nil, fmt.Errorf("config file error: %w", err)
 	}
 
-	conf := &lint.Config{
+	conf, err := Decode(confBytes)
+	if err != nil {
+		return nil, err
+	}
+	conf.Dir = filepath.Dir(confPath)
+	return conf, nil
+}
+
+// Decode parses given yaml config, and return Config instance, error if any
+// JSON config is accepted too, as it is valid yaml
+func Decode(confBytes []byte) (*lint.Config, error) {
+	conf := newDecodeConfig()
+
+	err := yaml.UnmarshalStrict(confBytes, conf)
+	if err != nil {
+		return nil, fmt.Errorf("config file error: %w", err)
+	}
+
+	err = checkDecoded(conf)
+	if err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+// DecodeWith parses given yaml config same as Decode, top level fields
+// which are not part of lint.Config are decoded to ext, so that other tools
+// can keep their config in the same file. ext should be a pointer to struct
+// with yaml tags, unknown fields are errors same as Decode
+func DecodeWith(confBytes []byte, ext interface{}) (*lint.Config, error) {
+	extVal := reflect.ValueOf(ext)
+	if extVal.Kind() != reflect.Ptr || extVal.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config extension should be a pointer to struct, but got %T", ext)
+	}
+
+	// unknown top level fields are checked first, as yaml errors
+	// would have the name of combined type below
+	var fields yaml.MapSlice
+	err := yaml.Unmarshal(confBytes, &fields)
+	if err != nil {
+		return nil, fmt.Errorf("config file error: %w", err)
+	}
+	known := fieldNames(reflect.TypeOf(lint.Config{}))
+	for name := range fieldNames(extVal.Elem().Type()) {
+		known[name] = true
+	}
+	for _, f := range fields {
+		if !known[fmt.Sprint(f.Key)] {
+			return nil, fmt.Errorf("config file error: unknown field '%v'", f.Key)
+		}
+	}
+
+	// both are inlined in one struct, so yaml fields are decoded strictly
+	fileType := reflect.StructOf([]reflect.StructField{
+		{Name: "Lint", Type: reflect.TypeOf(lint.Config{}), Tag: `yaml:",inline"`},
+		{Name: "Ext", Type: extVal.Elem().Type(), Tag: `yaml:",inline"`},
+	})
+	file := reflect.New(fileType).Elem()
+	file.Field(0).Set(reflect.ValueOf(*newDecodeConfig()))
+	file.Field(1).Set(extVal.Elem())
+
+	err = yaml.UnmarshalStrict(confBytes, file.Addr().Interface())
+	if err != nil {
+		return nil, fmt.Errorf("config file error: %w", err)
+	}
+
+	conf := file.Field(0).Interface().(lint.Config)
+	err = checkDecoded(&conf)
+	if err != nil {
+		return nil, err
+	}
+	extVal.Elem().Set(file.Field(1))
+	return &conf, nil
+}
+
+// newDecodeConfig returns the config with defaults for fields missing in file
+func newDecodeConfig() *lint.Config {
+	return &lint.Config{
 		MinVersion: internal.Version(),
 		Formatter:  (&formatter.DefaultFormatter{}).Name(),
 		Severity: lint.SeverityConfig{
 			Default: lint.SeverityError,
 		},
 	}
+}
 
-	err = yaml.UnmarshalStrict(confBytes, conf)
-	if err != nil {
-		return nil, fmt.Errorf("config file error: %w", err)
+func checkDecoded(conf *lint.Config) error {
+	if conf.Formatter == "" {
+		return errors.New("config error: formatter is empty")
 	}
+	return isValidVersion(conf.MinVersion)
+}
 
-	if conf.Formatter == "" {
-		return nil, errors.New("config error: formatter is empty")
+// checkRuleParser checks if enabled rules are supported by the parser config
+// gitmoji-enum needs emoji parser, else every header is missing the gitmoji
+func checkRuleParser(conf *lint.Config) error {
+	if conf.Parser.Emoji {
+		return nil
 	}
 
-	err = isValidVersion(conf.MinVersion)
-	if err != nil {
-		return nil, err
+	gitmojiEnum := (&rule.GitmojiEnumRule{}).Name()
+	for _, ruleName := range conf.Rules {
+		if ruleName == gitmojiEnum {
+			return fmt.Errorf("rule '%s' needs emoji parser, set 'parser.emoji' to true", ruleName)
+		}
 	}
-	return conf, nil
+	return nil
 }
 
 // Validate validates given config instance, it checks the following
 // If formatters, rules are registered/known
 // If arguments to rules are valid
+// If enabled rules are supported by parser config
 // If version is valid and atleast minimum than commitlint version used
 func Validate(conf *lint.Config) []error {
 	var errs []error
@@ -70,6 +159,23 @@ func Validate(conf *lint.Config) []error {
 		}
 	}
 
+	_, err = lint.NewParser(conf.Parser)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	err = checkRuleParser(conf)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	for _, pattern := range conf.Ignores {
+		_, err := regexp.Compile(pattern)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid ignore pattern '%s': %w", pattern, err))
+		}
+	}
+
 	// Check Severity Level
 	if !isSeverityValid(conf.Severity.Default) {
 		errs = append(errs, fmt.Errorf("unknown default severity level '%s'", conf.Severity.Default))
@@ -91,7 +197,7 @@ func Validate(conf *lint.Config) []error {
 		}
 	}
 
-	for ruleName, ruleSetting := range conf.Settings {
+	for ruleName := range conf.Settings {
 		// Check if rule is registered
 		ruleData, ok := registry.GetRule(ruleName)
 		if !ok {
@@ -99,7 +205,7 @@ func Validate(conf *lint.Config) []error {
 			continue
 		}
 
-		err := ruleData.Apply(ruleSetting)
+		err := ruleData.Apply(conf.GetRule(ruleName))
 		if err != nil {
 			errs = append(errs, err)
 		}
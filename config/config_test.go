@@ -0,0 +1,108 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zexot-com/commitlint/lint"
+)
+
+type extConfig struct {
+	Tool struct {
+		Name string `yaml:"name"`
+	} `yaml:"tool"`
+}
+
+func TestDecodeWith(t *testing.T) {
+	tests := []struct {
+		name string
+		conf string
+		err  string
+	}{
+		{"with ext", "version: v0.1.0\nrules: [type-enum]\ntool:\n  name: x\n", ""},
+		{"without ext", "rules: [type-enum]\n", ""},
+		{"unknown field", "rules: [type-enum]\nrule: [type-enum]\n", "config file error: unknown field 'rule'"},
+		{"unknown ext field", "tool:\n  nme: x\n", "field nme not found"},
+		{"invalid version", "version: 1\n", "invalid version"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ext := &extConfig{}
+			conf, err := DecodeWith([]byte(tc.conf), ext)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("got error %v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(conf.Rules) != 1 || conf.Formatter == "" || conf.Severity.Default == "" {
+				t.Errorf("unexpected config %+v", conf)
+			}
+			if strings.Contains(tc.conf, "tool:") && ext.Tool.Name != "x" {
+				t.Errorf("ext is not decoded, got %+v", ext)
+			}
+		})
+	}
+
+	_, err := Decode([]byte("tool:\n  name: x\n"))
+	if err == nil {
+		t.Error("expected error for ext field in Decode")
+	}
+}
+
+func TestSchemaWith(t *testing.T) {
+	s := SchemaWith(&extConfig{})
+
+	errs := ValidateSchema(s, []byte("rules: [type-enum]\ntool:\n  name: x\n"))
+	if len(errs) != 0 {
+		t.Errorf("config with ext is invalid: %v", errs)
+	}
+
+	errs = ValidateSchema(Schema(), []byte("tool:\n  name: x\n"))
+	if len(errs) != 1 {
+		t.Errorf("expected ext field to be unknown in Schema, got %v", errs)
+	}
+}
+
+func TestValidateGitmojiEnumParser(t *testing.T) {
+	tests := []struct {
+		name  string
+		emoji bool
+		err   string
+	}{
+		{"emoji parser", true, ""},
+		{"without emoji parser", false, "rule 'gitmoji-enum' needs emoji parser"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conf := &lint.Config{
+				MinVersion: "v0.1.0",
+				Formatter:  "default",
+				Severity:   lint.SeverityConfig{Default: lint.SeverityError},
+				Rules:      []string{"gitmoji-enum"},
+				Settings:   map[string]lint.RuleSetting{"gitmoji-enum": {}},
+				Parser:     lint.ParserConfig{Emoji: tc.emoji},
+			}
+
+			errs := Validate(conf)
+			_, linterErr := NewLinter(conf)
+			if tc.err == "" {
+				if len(errs) != 0 || linterErr != nil {
+					t.Fatalf("got errors %v, %v, want none", errs, linterErr)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), tc.err) {
+				t.Errorf("got errors %v, want %q", errs, tc.err)
+			}
+			if linterErr == nil || !strings.Contains(linterErr.Error(), tc.err) {
+				t.Errorf("got linter error %v, want %q", linterErr, tc.err)
+			}
+		})
+	}
+}
@@ -130,6 +130,81 @@ func NewDefault() *lint.Config {
 		(&rule.FooterTypeEnumRule{}).Name(): {
 			Argument: []map[interface{}]interface{}{},
 		},
+
+		// References Required Rule
+		(&rule.ReferencesRequiredRule{}).Name(): {
+			Argument: "[A-Z][A-Z0-9]+-[0-9]+",
+			Flags: map[string]interface{}{
+				"locations":       []interface{}{"description", "footer"},
+				"footer-tokens":   []interface{}{"Refs"},
+				"projects":        []interface{}{},
+				"branch-fallback": false,
+			},
+		},
+
+		// Signed Off By Rule
+		(&rule.SignedOffByRule{}).Name(): {
+			Flags: map[string]interface{}{
+				"match-identity": false,
+				"co-authored-by": true,
+			},
+		},
+
+		// Trailer Duplicate Rule
+		(&rule.TrailerDuplicateRule{}).Name(): {
+			Argument: []interface{}{"Signed-off-by", "Co-authored-by"},
+		},
+
+		// Trailer Order Rule
+		(&rule.TrailerOrderRule{}).Name(): {
+			Argument: []interface{}{},
+		},
+
+		// Trailer Token Case Rule
+		(&rule.TrailerTokenCaseRule{}).Name(): {
+			Argument: []interface{}{
+				"BREAKING CHANGE", "Signed-off-by", "Co-authored-by",
+				"Reviewed-by", "Acked-by", "Refs",
+			},
+		},
+
+		// Trailer Value Pattern Rule
+		(&rule.TrailerValuePatternRule{}).Name(): {
+			Argument: []interface{}{},
+		},
+
+		// No Secrets Rule
+		(&rule.NoSecretsRule{}).Name(): {
+			Argument: []interface{}{},
+			Flags: map[string]interface{}{
+				"allowlist":          []interface{}{},
+				"entropy":            4.0,
+				"entropy-min-length": 20,
+			},
+		},
+
+		// Description Imperative Rule
+		(&rule.DescriptionImperativeRule{}).Name(): {},
+
+		// Spelling Rule
+		(&rule.SpellingRule{}).Name(): {
+			Argument: []interface{}{"description", "body"},
+			Flags: map[string]interface{}{
+				"words":      []interface{}{},
+				"word-files": []interface{}{},
+			},
+		},
+
+		// Gitmoji Enum Rule
+		(&rule.GitmojiEnumRule{}).Name(): {
+			Argument: []interface{}{},
+			Flags: map[string]interface{}{
+				"allow-empty": false,
+				"check-type":  false,
+				"format":      "any",
+				"types":       map[interface{}]interface{}{},
+			},
+		},
 	}
 
 	def := &lint.Config{
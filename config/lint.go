@@ -8,7 +8,7 @@ import (
 )
 
 // NewLinter returns Linter for given confFilePath
-func NewLinter(conf *lint.Config) (*lint.Linter, error) {
+func NewLinter(conf *lint.Config, opts ...lint.Option) (*lint.Linter, error) {
 	err := checkIfMinVersion(conf.MinVersion)
 	if err != nil {
 		return nil, err
@@ -19,7 +19,7 @@ func NewLinter(conf *lint.Config) (*lint.Linter, error) {
 		return nil, err
 	}
 
-	return lint.New(conf, rules)
+	return lint.New(conf, rules, opts...)
 }
 
 // GetFormatter returns the formatter as defined in conf
@@ -38,6 +38,11 @@ func GetFormatter(conf *lint.Config) (lint.Formatter, error) {
 
 // GetEnabledRules forms Rule object for rules which are enabled in config
 func GetEnabledRules(conf *lint.Config) ([]lint.Rule, error) {
+	err := checkRuleParser(conf)
+	if err != nil {
+		return nil, fmt.Errorf("config error: %v", err)
+	}
+
 	enabledRules := make([]lint.Rule, 0, len(conf.Rules))
 
 	// To check if duplicate rule is added
@@ -55,12 +60,11 @@ func GetEnabledRules(conf *lint.Config) ([]lint.Rule, error) {
 			return nil, fmt.Errorf("config error: '%s' rule not found", ruleName)
 		}
 
-		rConf, ok := conf.Settings[ruleName]
-		if !ok {
+		if _, ok := conf.Settings[ruleName]; !ok {
 			return nil, fmt.Errorf("config error: '%s' rule settings not found", ruleName)
 		}
 
-		err := r.Apply(rConf)
+		err := r.Apply(conf.GetRule(ruleName))
 		if err != nil {
 			return nil, fmt.Errorf("config error: %v", err)
 		}
@@ -0,0 +1,382 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/zexot-com/commitlint/internal"
+	"github.com/zexot-com/commitlint/internal/registry"
+	"github.com/zexot-com/commitlint/lint"
+)
+
+const (
+	schemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+	// SchemaFileName is the name of schema file shipped with releases
+	SchemaFileName = "commitlint.schema.json"
+
+	// maxEnumInError is the max enum values listed in schema errors
+	maxEnumInError = 10
+)
+
+// Schema returns the JSON Schema of config, argument and flags of rules are
+// taken from registered rules, rules which does not implement
+// lint.SchemaRule accept any argument and flags
+func Schema() lint.Schema {
+	s := typeSchema(reflect.TypeOf(lint.Config{}))
+	s["$schema"] = schemaDraft
+	s["$id"] = "https://github.com/zexot-com/commitlint/releases/download/" + internal.Version() + "/" + SchemaFileName
+	s["title"] = "commitlint config"
+
+	allRules := registry.Rules()
+	sort.Slice(allRules, func(i, j int) bool { return allRules[i].Name() < allRules[j].Name() })
+
+	ruleNames := make([]string, 0, len(allRules))
+	settings := make(map[string]lint.Schema, len(allRules))
+	for _, r := range allRules {
+		ruleNames = append(ruleNames, r.Name())
+		settings[r.Name()] = ruleSettingSchema(r)
+	}
+
+	allFormatters := registry.Formatters()
+	formatNames := make([]string, 0, len(allFormatters))
+	for _, f := range allFormatters {
+		formatNames = append(formatNames, f.Name())
+	}
+	sort.Strings(formatNames)
+
+	props := properties(s)
+	props["formatter"]["enum"] = formatNames
+	props["rules"]["items"] = lint.Schema{"type": "string", "enum": ruleNames}
+	props["settings"] = lint.Schema{
+		"type":                 "object",
+		"properties":           settings,
+		"additionalProperties": false,
+	}
+	properties(props["severity"])["rules"]["propertyNames"] = lint.Schema{"enum": ruleNames}
+	properties(props["parser"])["name"]["enum"] = []string{lint.ParserConventional, lint.ParserRegex}
+	return s
+}
+
+// SchemaWith returns Schema with the top level fields of ext, which is
+// decoded by DecodeWith. ext should be a pointer to struct with yaml tags
+func SchemaWith(ext interface{}) lint.Schema {
+	s := Schema()
+	props := properties(s)
+	for name, p := range properties(typeSchema(reflect.TypeOf(ext))) {
+		props[name] = p
+	}
+	return s
+}
+
+// ValidateSchema validates given yaml config against schema s
+// returned by Schema or SchemaWith
+func ValidateSchema(s lint.Schema, confBytes []byte) []error {
+	var conf interface{}
+	err := yaml.Unmarshal(confBytes, &conf)
+	if err != nil {
+		return []error{fmt.Errorf("config file error: %w", err)}
+	}
+
+	// empty config uses the defaults
+	if conf == nil {
+		return nil
+	}
+	return validateSchema(s, conf, "")
+}
+
+// ruleSettingSchema returns the schema of rule settings
+func ruleSettingSchema(r lint.Rule) lint.Schema {
+	argument := lint.Schema{}
+	flags := lint.Schema{"type": "object"}
+
+	if sr, ok := r.(lint.SchemaRule); ok {
+		argument = sr.ArgumentSchema()
+
+		flagProps := sr.FlagsSchema()
+		if flagProps == nil {
+			flagProps = map[string]lint.Schema{}
+		}
+		flags["properties"] = flagProps
+		flags["additionalProperties"] = false
+	}
+
+	return lint.Schema{
+		"type": "object",
+		"properties": map[string]lint.Schema{
+			"argument": argument,
+			"flags":    flags,
+		},
+		"additionalProperties": false,
+	}
+}
+
+// typeSchema returns schema of config type, field names are taken from yaml tags
+func typeSchema(t reflect.Type) lint.Schema {
+	if t == reflect.TypeOf(lint.Severity("")) {
+		return lint.Schema{"type": "string", "enum": []string{string(lint.SeverityError), string(lint.SeverityWarn)}}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return lint.Schema{"type": "string"}
+	case reflect.Bool:
+		return lint.Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return lint.Schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return lint.Schema{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return lint.Schema{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return lint.Schema{"type": "object", "additionalProperties": typeSchema(t.Elem())}
+	case reflect.Ptr:
+		return typeSchema(t.Elem())
+	case reflect.Struct:
+		props := make(map[string]lint.Schema, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if name, ok := fieldName(field); ok {
+				props[name] = typeSchema(field.Type)
+			}
+		}
+		return lint.Schema{"type": "object", "properties": props, "additionalProperties": false}
+	default:
+		return lint.Schema{}
+	}
+}
+
+// fieldName returns the yaml field name of struct field, false if it is
+// not decoded from yaml
+func fieldName(field reflect.StructField) (string, bool) {
+	if field.PkgPath != "" {
+		return "", false
+	}
+
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "-" {
+		return "", false
+	}
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+	return name, true
+}
+
+// fieldNames returns the yaml field names of struct type t
+func fieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if name, ok := fieldName(t.Field(i)); ok {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// properties returns properties of object schema s
+func properties(s lint.Schema) map[string]lint.Schema {
+	return s["properties"].(map[string]lint.Schema)
+}
+
+// validateSchema validates value v decoded from yaml against schema s
+// supports type, enum, properties, additionalProperties, propertyNames,
+// required and items keywords, which are used by config schema
+func validateSchema(s lint.Schema, v interface{}, path string) []error {
+	if typ, ok := s["type"]; ok {
+		types := toStrings(typ)
+		if !hasType(types, v) {
+			return []error{fmt.Errorf("%s: expects %s, but got %s", pathName(path), strings.Join(types, " or "), typeName(v))}
+		}
+	}
+
+	if enum, ok := s["enum"]; ok {
+		if err := checkEnum(toStrings(enum), v, path); err != nil {
+			return []error{err}
+		}
+	}
+
+	var errs []error
+	switch val := v.(type) {
+	case []interface{}:
+		if items, ok := toSchema(s["items"]); ok {
+			for i, item := range val {
+				errs = append(errs, validateSchema(items, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	case map[interface{}]interface{}, map[string]interface{}:
+		errs = append(errs, validateObject(s, toMap(val), path)...)
+	}
+	return errs
+}
+
+func validateObject(s lint.Schema, obj map[string]interface{}, path string) []error {
+	var errs []error
+
+	for _, name := range toStrings(s["required"]) {
+		if _, ok := obj[name]; !ok {
+			errs = append(errs, fmt.Errorf("%s: missing property '%s'", pathName(path), name))
+		}
+	}
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	props := toSchemaMap(s["properties"])
+	propNames, hasPropNames := toSchema(s["propertyNames"])
+	for _, k := range keys {
+		propPath := k
+		if path != "" {
+			propPath = path + "." + k
+		}
+
+		if hasPropNames {
+			errs = append(errs, validateSchema(propNames, k, propPath)...)
+		}
+
+		if propSchema, ok := props[k]; ok {
+			errs = append(errs, validateSchema(propSchema, obj[k], propPath)...)
+			continue
+		}
+
+		switch additional := s["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				errs = append(errs, fmt.Errorf("%s: unknown property '%s'", pathName(path), k))
+			}
+		default:
+			if addSchema, ok := toSchema(additional); ok {
+				errs = append(errs, validateSchema(addSchema, obj[k], propPath)...)
+			}
+		}
+	}
+	return errs
+}
+
+func checkEnum(enum []string, v interface{}, path string) error {
+	str, ok := v.(string)
+	if ok {
+		for _, e := range enum {
+			if e == str {
+				return nil
+			}
+		}
+	}
+
+	if len(enum) > maxEnumInError {
+		return fmt.Errorf("%s: unknown value '%v'", pathName(path), v)
+	}
+	return fmt.Errorf("%s: unknown value '%v', should be one of %v", pathName(path), v, enum)
+}
+
+func hasType(types []string, v interface{}) bool {
+	for _, t := range types {
+		if t == typeName(v) || (t == "number" && typeName(v) == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// typeName returns JSON Schema type name of value decoded from yaml
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case int, int64, uint64:
+		return "integer"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[interface{}]interface{}, map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+func pathName(path string) string {
+	if path == "" {
+		return "config"
+	}
+	return path
+}
+
+// toSchema converts a schema keyword value, which can be lint.Schema or
+// map from custom rules, to lint.Schema
+func toSchema(v interface{}) (lint.Schema, bool) {
+	switch s := v.(type) {
+	case lint.Schema:
+		return s, true
+	case map[string]interface{}:
+		return lint.Schema(s), true
+	default:
+		return nil, false
+	}
+}
+
+func toSchemaMap(v interface{}) map[string]lint.Schema {
+	switch m := v.(type) {
+	case map[string]lint.Schema:
+		return m
+	case lint.Schema, map[string]interface{}:
+		props := make(map[string]lint.Schema)
+		for k, p := range toMap(m) {
+			if s, ok := toSchema(p); ok {
+				props[k] = s
+			}
+		}
+		return props
+	default:
+		return nil
+	}
+}
+
+func toMap(v interface{}) map[string]interface{} {
+	out := make(map[string]interface{})
+	switch m := v.(type) {
+	case lint.Schema:
+		for k, val := range m {
+			out[k] = val
+		}
+	case map[string]interface{}:
+		for k, val := range m {
+			out[k] = val
+		}
+	case map[interface{}]interface{}:
+		for k, val := range m {
+			out[fmt.Sprint(k)] = val
+		}
+	}
+	return out
+}
+
+func toStrings(v interface{}) []string {
+	switch s := v.(type) {
+	case string:
+		return []string{s}
+	case []string:
+		return s
+	case []interface{}:
+		strs := make([]string, 0, len(s))
+		for _, e := range s {
+			strs = append(strs, fmt.Sprint(e))
+		}
+		return strs
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/zexot-com/commitlint/lint"
+)
+
+func TestSchemaDefaultConfig(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteTo(&buf, NewDefault())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs := ValidateSchema(Schema(), buf.Bytes())
+	if len(errs) != 0 {
+		t.Errorf("default config is invalid: %v", errs)
+	}
+}
+
+func TestValidateSchema(t *testing.T) {
+	tests := []struct {
+		name string
+		conf string
+		errs []string
+	}{
+		{"valid", "version: v0.1.0\nrules: [type-enum]\nsettings:\n  type-enum:\n    argument: [feat]\n    flags:\n      accept-aliases: true\n", nil},
+		{"empty", "", nil},
+		{"wrong argument type", "settings:\n  header-max-length:\n    argument: fifty\n", []string{"settings.header-max-length.argument: expects integer, but got string"}},
+		{"unknown flag", "settings:\n  scope-enum:\n    argument: []\n    flags:\n      allow-empy: true\n", []string{"settings.scope-enum.flags: unknown property 'allow-empy'"}},
+		{"unknown unit", "settings:\n  body-max-length:\n    argument: 10\n    flags:\n      unit: words\n", []string{"settings.body-max-length.flags.unit: unknown value 'words', should be one of [bytes runes graphemes columns]"}},
+		{"missing param", "settings:\n  trailer-value-pattern:\n    argument:\n      - token: Refs\n", []string{"settings.trailer-value-pattern.argument[0]: missing property 'pattern'"}},
+		{"severity", "severity:\n  default: fatal\n  rules:\n    unknown-rule: warn\n", []string{"severity.default: unknown value 'fatal', should be one of [error warn]", "severity.rules.unknown-rule: unknown value 'unknown-rule'"}},
+		{"unknown field", "rule: [type-enum]\n", []string{"config: unknown property 'rule'"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateSchema(Schema(), []byte(tc.conf))
+
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Error())
+			}
+			if strings.Join(got, "\n") != strings.Join(tc.errs, "\n") {
+				t.Errorf("got errors %q, want %q", got, tc.errs)
+			}
+		})
+	}
+}
+
+type customRule struct{}
+
+func (r *customRule) Name() string                             { return "custom" }
+func (r *customRule) Apply(setting lint.RuleSetting) error     { return nil }
+func (r *customRule) Validate(lint.Commit) (*lint.Issue, bool) { return nil, true }
+
+func TestRuleSettingSchemaCustomRule(t *testing.T) {
+	s := ruleSettingSchema(&customRule{})
+
+	var conf interface{} = map[interface{}]interface{}{
+		"argument": map[interface{}]interface{}{"any": []interface{}{1, "value"}},
+		"flags":    map[interface{}]interface{}{"any-flag": true},
+	}
+	errs := validateSchema(s, conf, "settings.custom")
+	if len(errs) != 0 {
+		t.Errorf("custom rule settings are invalid: %v", errs)
+	}
+}
@@ -13,6 +13,8 @@ const (
 	truncateSize = 25
 )
 
+var _ lint.BatchFormatter = (*DefaultFormatter)(nil)
+
 // DefaultFormatter represent default formatter
 type DefaultFormatter struct{}
 
@@ -21,17 +23,27 @@ func (f *DefaultFormatter) Name() string { return "default" }
 
 // Format formats the lint.Failure
 func (f *DefaultFormatter) Format(result *lint.Result) (string, error) {
-	return f.formatFailure(result.Input(), result.Issues()), nil
+	return f.formatFailure(result.Input(), result.Issues(), true), nil
 }
 
-func (f *DefaultFormatter) formatFailure(msg string, issues []*lint.Issue) string {
+// FormatBatch formats each result with a 'kind id' header line, fixable
+// issues have no fix hint as --fix works only for a single message
+func (f *DefaultFormatter) FormatBatch(results []lint.BatchResult) (string, error) {
+	outputs := make([]string, 0, len(results))
+	for _, r := range results {
+		outputs = append(outputs, r.Kind+" "+r.ID+"\n"+f.formatFailure(r.Result.Input(), r.Result.Issues(), false))
+	}
+	return strings.Join(outputs, "\n\n"), nil
+}
+
+func (f *DefaultFormatter) formatFailure(msg string, issues []*lint.Issue, showFixHint bool) string {
 	if len(issues) == 0 {
 		return " ✔ commit message"
 	}
-	return f.writeFailure(msg, issues)
+	return f.writeFailure(msg, issues, showFixHint)
 }
 
-func (f *DefaultFormatter) writeFailure(msg string, issues []*lint.Issue) string {
+func (f *DefaultFormatter) writeFailure(msg string, issues []*lint.Issue, showFixHint bool) string {
 	str := &strings.Builder{}
 
 	quotedStr := strconv.Quote(truncate(truncateSize, msg))
@@ -41,26 +53,26 @@ func (f *DefaultFormatter) writeFailure(msg string, issues []*lint.Issue) string
 
 	errs, warns, others := f.bySeverity(issues)
 
-	f.writeIssues(str, "❌", "Errors", errs)
-	f.writeIssues(str, "!", "Warnings", warns)
-	f.writeIssues(str, "?", "Other Severities", others)
+	f.writeIssues(str, "❌", "Errors", errs, showFixHint)
+	f.writeIssues(str, "!", "Warnings", warns, showFixHint)
+	f.writeIssues(str, "?", "Other Severities", others, showFixHint)
 
 	fmt.Fprintf(str, "\n\nTotal %d errors, %d warnings, %d other severities", len(errs), len(warns), len(others))
 	return strings.Trim(str.String(), "\n")
 }
 
-func (f *DefaultFormatter) writeIssues(w *strings.Builder, sign, title string, issues []*lint.Issue) {
+func (f *DefaultFormatter) writeIssues(w *strings.Builder, sign, title string, issues []*lint.Issue, showFixHint bool) {
 	if len(issues) == 0 {
 		return
 	}
 
 	w.WriteString("\n\n" + title + ":")
 	for _, issue := range issues {
-		f.writeIssue(w, sign, issue)
+		f.writeIssue(w, sign, issue, showFixHint)
 	}
 }
 
-func (f *DefaultFormatter) writeIssue(w *strings.Builder, sign string, issue *lint.Issue) {
+func (f *DefaultFormatter) writeIssue(w *strings.Builder, sign string, issue *lint.Issue, showFixHint bool) {
 	space := "  "
 
 	// ❌ rule-name: description
@@ -71,6 +83,9 @@ func (f *DefaultFormatter) writeIssue(w *strings.Builder, sign string, issue *li
 	for _, msg := range issue.Infos() {
 		fmt.Fprintf(w, "\n%s - %s", space+space, msg)
 	}
+	if showFixHint && issue.Fix() != "" {
+		fmt.Fprintf(w, "\n%s - %s", space+space, "fixable, run 'commitlint lint --fix'")
+	}
 }
 
 // bySeverity returns all messages with given severity
@@ -8,6 +8,8 @@ import (
 	"github.com/zexot-com/commitlint/lint"
 )
 
+var _ lint.BatchFormatter = (*JSONFormatter)(nil)
+
 // JSONFormatter represent default formatter
 type JSONFormatter struct{}
 
@@ -16,11 +18,32 @@ func (f *JSONFormatter) Name() string { return "json" }
 
 // Format formats the lint.Result
 func (f *JSONFormatter) Format(result *lint.Result) (string, error) {
+	return f.marshal(f.formatResult(result))
+}
+
+// FormatBatch formats the results as array of objects with kind, id and
+// result, like {"kind": "commit", "id": "a1b2c3d", "result": {...}}
+func (f *JSONFormatter) FormatBatch(results []lint.BatchResult) (string, error) {
+	output := make([]interface{}, 0, len(results))
+	for _, r := range results {
+		output = append(output, map[string]interface{}{
+			"kind":   r.Kind,
+			"id":     r.ID,
+			"result": f.formatResult(r.Result),
+		})
+	}
+	return f.marshal(output)
+}
+
+func (f *JSONFormatter) formatResult(result *lint.Result) map[string]interface{} {
 	output := make(map[string]interface{}, 4)
 
 	output["input"] = result.Input()
-	output["issues"] = f.formatIssue(result.Issues())
+	output["issues"] = f.formatIssue(result)
+	return output
+}
 
+func (f *JSONFormatter) marshal(output interface{}) (string, error) {
 	formatted, err := json.Marshal(output)
 	if err != nil {
 		return "", fmt.Errorf("json formatting failed: %w", err)
@@ -28,10 +51,10 @@ func (f *JSONFormatter) Format(result *lint.Result) (string, error) {
 	return strings.Trim(string(formatted), "\n"), nil
 }
 
-func (f *JSONFormatter) formatIssue(issues []*lint.Issue) []interface{} {
-	formattedIssues := make([]interface{}, 0, len(issues))
+func (f *JSONFormatter) formatIssue(result *lint.Result) []interface{} {
+	formattedIssues := make([]interface{}, 0, len(result.Issues()))
 
-	for _, issue := range issues {
+	for _, issue := range result.Issues() {
 		output := make(map[string]interface{})
 
 		output["name"] = issue.RuleName()
@@ -42,6 +65,14 @@ func (f *JSONFormatter) formatIssue(issues []*lint.Issue) []interface{} {
 			output["infos"] = issue.Infos()
 		}
 
+		if len(issue.Suggestions()) > 0 {
+			output["suggestions"] = issue.Suggestions()
+		}
+
+		if issue.Fix() != "" {
+			output["fix"] = result.Redacted(issue.Fix())
+		}
+
 		formattedIssues = append(formattedIssues, output)
 	}
 
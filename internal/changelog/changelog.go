@@ -0,0 +1,281 @@
+// Package changelog generates changelog from conventional commit history
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/zexot-com/commitlint/internal/git"
+	"github.com/zexot-com/commitlint/lint"
+)
+
+// SectionConfig represent a changelog section for a commit type
+type SectionConfig struct {
+	Type  string `yaml:"type"`
+	Title string `yaml:"title"`
+}
+
+// Config represent config for changelog command
+type Config struct {
+	// Sections are the commit types included in changelog in given order
+	// commits with other types are skipped, unless they are breaking changes
+	Sections []SectionConfig `yaml:"sections,omitempty"`
+
+	// CommitURL is the link to commit, '{hash}' is replaced with commit hash
+	// like 'https://github.com/org/repo/commit/{hash}'
+	CommitURL string `yaml:"commit-url,omitempty"`
+
+	// ReferencePattern is regex of issue references, like 'PAY-1234'
+	ReferencePattern string `yaml:"reference-pattern,omitempty"`
+
+	// ReferenceURL is the link to reference, '{ref}' is replaced with reference
+	// like 'https://jira.example.com/browse/{ref}'
+	ReferenceURL string `yaml:"reference-url,omitempty"`
+}
+
+// defaultSections are used when changelog config has no sections
+var defaultSections = []SectionConfig{
+	{Type: "feat", Title: "Features"},
+	{Type: "fix", Title: "Bug Fixes"},
+	{Type: "perf", Title: "Performance Improvements"},
+	{Type: "revert", Title: "Reverts"},
+}
+
+// defaultReferencePattern matches jira like keys and github issues
+const defaultReferencePattern = `[A-Z][A-Z0-9]+-[0-9]+|#[0-9]+`
+
+const dateFormat = "2006-01-02"
+
+// Reference represent an issue reference in commit message
+type Reference struct {
+	ID  string `json:"id"`
+	URL string `json:"url,omitempty"`
+}
+
+// Entry represent a commit in changelog
+type Entry struct {
+	Hash          string      `json:"hash"`
+	URL           string      `json:"url,omitempty"`
+	Type          string      `json:"type"`
+	Scope         string      `json:"scope,omitempty"`
+	Description   string      `json:"description"`
+	Breaking      bool        `json:"breaking"`
+	BreakingNotes []string    `json:"breaking_notes,omitempty"`
+	References    []Reference `json:"references,omitempty"`
+}
+
+// Section represent the entries of a commit type
+type Section struct {
+	Type    string   `json:"type"`
+	Title   string   `json:"title"`
+	Entries []*Entry `json:"entries"`
+}
+
+// Changelog represent the changes in a release, newest entries first
+type Changelog struct {
+	Title    string     `json:"title"`
+	Date     string     `json:"date"`
+	Breaking []*Entry   `json:"breaking"`
+	Sections []*Section `json:"sections"`
+}
+
+// Generator generates changelog from commits
+type Generator struct {
+	linter     *lint.Linter
+	conf       Config
+	refPattern *regexp.Regexp
+}
+
+// New returns a changelog Generator, commits are parsed and ignored
+// same as the linter
+func New(linter *lint.Linter, conf Config) (*Generator, error) {
+	if len(conf.Sections) == 0 {
+		conf.Sections = defaultSections
+	}
+
+	pattern := conf.ReferencePattern
+	if pattern == "" {
+		pattern = defaultReferencePattern
+	}
+	refPattern, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid changelog reference pattern: %w", err)
+	}
+
+	g := &Generator{
+		linter:     linter,
+		conf:       conf,
+		refPattern: refPattern,
+	}
+	return g, nil
+}
+
+// Generate returns the changelog for commits ordered oldest first as returned
+// by git log. Ignored commits and commits which can not be parsed are skipped
+func (g *Generator) Generate(title string, date time.Time, commits []*git.Commit) *Changelog {
+	c := &Changelog{
+		Title:    title,
+		Date:     date.Format(dateFormat),
+		Breaking: []*Entry{},
+		Sections: []*Section{},
+	}
+
+	byType := make(map[string]*Section, len(g.conf.Sections))
+	for _, s := range g.conf.Sections {
+		section := &Section{Type: s.Type, Title: s.Title, Entries: []*Entry{}}
+		byType[s.Type] = section
+		c.Sections = append(c.Sections, section)
+	}
+
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+		if g.linter.IsIgnored(commit.Message) {
+			continue
+		}
+
+		msg, err := g.linter.Parse(commit.Message)
+		if err != nil {
+			continue
+		}
+
+		entry := g.newEntry(commit, msg)
+		if entry.Breaking {
+			c.Breaking = append(c.Breaking, entry)
+		}
+		if section, ok := byType[entry.Type]; ok {
+			section.Entries = append(section.Entries, entry)
+		}
+	}
+
+	// drop empty sections
+	sections := c.Sections[:0]
+	for _, s := range c.Sections {
+		if len(s.Entries) > 0 {
+			sections = append(sections, s)
+		}
+	}
+	c.Sections = sections
+	return c
+}
+
+func (g *Generator) newEntry(commit *git.Commit, msg lint.Commit) *Entry {
+	entry := &Entry{
+		Hash:        commit.Hash,
+		Type:        g.linter.CommitType(msg),
+		Scope:       msg.Scope(),
+		Description: msg.Description(),
+		Breaking:    msg.IsBreakingChange(),
+	}
+
+	if g.conf.CommitURL != "" {
+		entry.URL = strings.ReplaceAll(g.conf.CommitURL, "{hash}", commit.Hash)
+	}
+
+	for _, t := range lint.CommitTrailers(msg) {
+		if t.IsBreakingChange() {
+			entry.BreakingNotes = append(entry.BreakingNotes, t.Value)
+		}
+	}
+
+	seen := make(map[string]struct{})
+	for _, ref := range g.refPattern.FindAllString(msg.Message(), -1) {
+		if _, ok := seen[ref]; ok {
+			continue
+		}
+		seen[ref] = struct{}{}
+
+		reference := Reference{ID: ref}
+		if g.conf.ReferenceURL != "" {
+			reference.URL = strings.ReplaceAll(g.conf.ReferenceURL, "{ref}", strings.TrimPrefix(ref, "#"))
+		}
+		entry.References = append(entry.References, reference)
+	}
+	return entry
+}
+
+// Markdown returns the changelog as markdown release section
+func (c *Changelog) Markdown() string {
+	w := &strings.Builder{}
+	fmt.Fprintf(w, "## %s (%s)\n", c.Title, c.Date)
+
+	if len(c.Breaking) == 0 && len(c.Sections) == 0 {
+		w.WriteString("\nNo notable changes\n")
+		return w.String()
+	}
+
+	if len(c.Breaking) > 0 {
+		w.WriteString("\n### ⚠ BREAKING CHANGES\n\n")
+		for _, e := range c.Breaking {
+			notes := e.BreakingNotes
+			if len(notes) == 0 {
+				notes = []string{e.Description}
+			}
+			for _, note := range notes {
+				// continuation lines of note are indented under the list item
+				note = strings.ReplaceAll(note, "\n", "\n  ")
+				fmt.Fprintf(w, "- %s%s %s\n", scopePrefix(e), note, commitLink(e))
+			}
+		}
+	}
+
+	for _, s := range c.Sections {
+		fmt.Fprintf(w, "\n### %s\n\n", s.Title)
+		for _, e := range s.Entries {
+			fmt.Fprintf(w, "- %s%s %s%s\n", scopePrefix(e), e.Description, commitLink(e), referenceLinks(e))
+		}
+	}
+	return w.String()
+}
+
+func scopePrefix(e *Entry) string {
+	if e.Scope == "" {
+		return ""
+	}
+	return "**" + e.Scope + ":** "
+}
+
+func commitLink(e *Entry) string {
+	short := e.Hash
+	if len(short) > 7 {
+		short = short[:7]
+	}
+	if e.URL == "" {
+		return "(" + short + ")"
+	}
+	return "([" + short + "](" + e.URL + "))"
+}
+
+func referenceLinks(e *Entry) string {
+	if len(e.References) == 0 {
+		return ""
+	}
+
+	refs := make([]string, len(e.References))
+	for i, ref := range e.References {
+		if ref.URL == "" {
+			refs[i] = ref.ID
+			continue
+		}
+		refs[i] = "[" + ref.ID + "](" + ref.URL + ")"
+	}
+	return ", refs " + strings.Join(refs, ", ")
+}
+
+// Prepend returns the existing changelog with section added on top
+// section is added after the document title if existing starts with one
+func Prepend(existing, section string) string {
+	if strings.TrimSpace(existing) == "" {
+		return section
+	}
+
+	title, rest, hasRest := strings.Cut(existing, "\n")
+	if !strings.HasPrefix(title, "# ") {
+		return section + "\n" + existing
+	}
+	if !hasRest {
+		return title + "\n\n" + section
+	}
+	return title + "\n\n" + section + "\n" + strings.TrimLeft(rest, "\n")
+}
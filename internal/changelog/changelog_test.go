@@ -0,0 +1,80 @@
+package changelog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zexot-com/commitlint/internal/git"
+	"github.com/zexot-com/commitlint/lint"
+	"github.com/zexot-com/commitlint/rule"
+)
+
+func TestGenerate(t *testing.T) {
+	conf := &lint.Config{Ignores: []string{`^WIP`}}
+	typeEnum := &rule.TypeEnumRule{Types: []string{"feat", "fix"}, Aliases: map[string]string{"feature": "feat"}}
+	linter, err := lint.New(conf, []lint.Rule{typeEnum})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := New(linter, Config{ReferenceURL: "https://jira/browse/{ref}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commits := []*git.Commit{
+		{Hash: "1111111aaaa", Message: "feat(api): add login\n\nRefs: PAY-12"},
+		{Hash: "2222222bbbb", Message: "chore: bump deps"},
+		{Hash: "3333333cccc", Message: "WIP: feat: half done"},
+		{Hash: "4444444dddd", Message: "not conventional"},
+		{Hash: "5555555eeee", Message: "fix!: drop v1\n\nBREAKING CHANGE: v1 removed"},
+		{Hash: "6666666ffff", Message: "Feature: add logout"},
+	}
+
+	date := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	c := g.Generate("v1.0.0", date, commits)
+
+	want := `## v1.0.0 (2026-01-02)
+
+### ⚠ BREAKING CHANGES
+
+- v1 removed (5555555)
+
+### Features
+
+- add logout (6666666)
+- **api:** add login (1111111), refs [PAY-12](https://jira/browse/PAY-12)
+
+### Bug Fixes
+
+- drop v1 (5555555)
+`
+	if got := c.Markdown(); got != want {
+		t.Errorf("unexpected markdown\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPrepend(t *testing.T) {
+	section := "## v2 (2026-01-02)\n\n- new\n"
+
+	tests := []struct {
+		existing string
+		want     string
+	}{
+		{"", section},
+		{"# Changelog\n\n## v1\n", "# Changelog\n\n" + section + "\n## v1\n"},
+		{"## v1\n", section + "\n## v1\n"},
+		{"# Changelog", "# Changelog\n\n" + section},
+	}
+
+	for _, test := range tests {
+		got := Prepend(test.existing, section)
+		if got != test.want {
+			t.Errorf("%q: expected %q, got %q", test.existing, test.want, got)
+		}
+		if !strings.Contains(got, section) {
+			t.Errorf("%q: section missing in %q", test.existing, got)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/zexot-com/commitlint/config"
+	"github.com/zexot-com/commitlint/internal/changelog"
+	"github.com/zexot-com/commitlint/internal/git"
+)
+
+// changelog output formats
+const (
+	changelogMarkdown = "markdown"
+	changelogJSON     = "json"
+)
+
+var (
+	errChangelogFormat  = errors.New("unknown changelog format, should be one of [markdown json]")
+	errChangelogPrepend = errors.New("--prepend supports only markdown format")
+)
+
+// changelogCmd is the callback function for changelog command
+func changelogCmd(confPath, from, to, title, format, prependPath string) error {
+	if format != changelogMarkdown && format != changelogJSON {
+		return handleError(errChangelogFormat, "Invalid changelog format")
+	}
+	if prependPath != "" && format != changelogMarkdown {
+		return handleError(errChangelogPrepend, "Invalid changelog format")
+	}
+
+	output, err := runChangelog(confPath, from, to, title, format)
+	if handleError(err, "Failed to generate changelog") != nil {
+		return err
+	}
+
+	if prependPath == "" {
+		fmt.Print(output)
+		return nil
+	}
+
+	prependPath = filepath.Clean(prependPath)
+	existing, err := os.ReadFile(prependPath)
+	if err != nil && !os.IsNotExist(err) {
+		return handleError(err, "Failed to read changelog file")
+	}
+
+	err = os.WriteFile(prependPath, []byte(changelog.Prepend(string(existing), output)), 0644)
+	return handleError(err, "Failed to write changelog file")
+}
+
+func runChangelog(confPath, from, to, title, format string) (string, error) {
+	conf, cmdConf, err := getCommandConfig(confPath)
+	if handleError(err, "Failed to get configuration") != nil {
+		return "", err
+	}
+
+	linter, err := config.NewLinter(conf)
+	if handleError(err, "Failed to create new linter") != nil {
+		return "", err
+	}
+
+	generator, err := changelog.New(linter, cmdConf.Changelog)
+	if handleError(err, "Failed to create changelog generator") != nil {
+		return "", err
+	}
+
+	if from == "" {
+		from, err = git.LatestTag(to)
+		if handleError(err, "Failed to find latest tag") != nil {
+			return "", err
+		}
+	}
+
+	// without tags, changelog includes the whole history
+	revRange := to
+	if from != "" {
+		revRange = from + ".." + to
+	}
+
+	commits, err := git.Log(revRange)
+	if handleError(err, "Failed to read commits in range") != nil {
+		return "", err
+	}
+
+	if title == "" {
+		title = to
+		if to == "HEAD" {
+			title = "Unreleased"
+		}
+	}
+
+	log := generator.Generate(title, time.Now(), commits)
+	if format == changelogJSON {
+		out, err := json.MarshalIndent(log, "", "  ")
+		if handleError(err, "Failed to format changelog") != nil {
+			return "", err
+		}
+		return string(out) + "\n", nil
+	}
+	return log.Markdown(), nil
+}
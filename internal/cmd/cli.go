@@ -7,15 +7,26 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/zexot-com/commitlint/internal"
+	"github.com/zexot-com/commitlint/internal/hook"
 )
 
 // newCliApp returns commitlint cli.App
 func newCliApp() *cli.App {
 	cmds := []*cli.Command{
 		newInitCmd(),
+		newDeinitCmd(),
 		newLintCmd(),
 		newConfigCmd(),
 		newHookCmd(),
+		newChangelogCmd(),
+		newNextVersionCmd(),
+		newReportCmd(),
+		newTemplateCmd(),
+		newPrepareCmd(),
+		newPreReceiveCmd(),
+		newPrePushCmd(),
+		newServeCmd(),
+		newLSPCmd(),
 		newDebugCmd(),
 	}
 
@@ -30,8 +41,9 @@ func newCliApp() *cli.App {
 
 func newLintCmd() *cli.Command {
 	return &cli.Command{
-		Name:  "lint",
-		Usage: "Check commit message against lint rules",
+		Name:      "lint",
+		Usage:     "Check commit message against lint rules",
+		ArgsUsage: "[patch files with --patch]",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:    "config",
@@ -45,11 +57,45 @@ func newLintCmd() *cli.Command {
 				Value:   "",
 				Usage:   "path to commit message `FILE`",
 			},
+			&cli.BoolFlag{
+				Name:  "fix",
+				Usage: "apply fixes suggested by rules, fixed message is written back to message file or printed for stdin",
+			},
+			&cli.StringFlag{
+				Name:  "range",
+				Value: "",
+				Usage: "lint commits in git revision `RANGE` like main..HEAD instead of a commit message",
+			},
+			&cli.StringFlag{
+				Name:  "cleanup",
+				Value: "",
+				Usage: "cleanup `MODE` of message file, default, strip, whitespace, scissors or verbatim, defaults to commit.cleanup config",
+			},
+			&cli.BoolFlag{
+				Name:  "patch",
+				Usage: "lint commit messages in patch or mbox files given as arguments, or in stdin, created by git format-patch",
+			},
 		},
 		Action: func(ctx *cli.Context) error {
 			confFilePath := ctx.String("config")
 			fileInput := ctx.String("message")
-			err := lintMsg(confFilePath, fileInput)
+			isFix := ctx.Bool("fix")
+			revRange := ctx.String("range")
+			if ctx.Bool("patch") {
+				if isFix || revRange != "" || fileInput != "" {
+					return handleError(errPatchFlags, "Failed to run lint command")
+				}
+				err := lintPatches(confFilePath, ctx.Args().Slice())
+				return handleError(err, "Failed to run lint command")
+			}
+			if revRange != "" {
+				if isFix {
+					return handleError(errFixRange, "Failed to run lint command")
+				}
+				err := lintRange(confFilePath, revRange)
+				return handleError(err, "Failed to run lint command")
+			}
+			err := lintMsg(confFilePath, fileInput, ctx.String("cleanup"), isFix)
 			return handleError(err, "Failed to run lint command")
 		},
 	}
@@ -60,23 +106,28 @@ func newInitCmd() *cli.Command {
 	replaceFlag := newReplaceFlag()
 	hooksFlag := newHooksPathFlag()
 
-	globalFlag := &cli.BoolFlag{
-		Name:    "global",
-		Aliases: []string{"g"},
-		Usage:   "Sets git hook in global config",
+	globalFlag := newGlobalFlag("Sets git hook in global config")
+
+	chainFlag := &cli.BoolFlag{
+		Name:  "chain",
+		Usage: "Installs commitlint hooks in git hooks directory without changing core.hooksPath, existing hooks of same name are kept",
 	}
 
 	return &cli.Command{
 		Name:  "init",
 		Usage: "Setup commitlint for git repos",
-		Flags: []cli.Flag{globalFlag, confFlag, replaceFlag, hooksFlag},
+		Flags: []cli.Flag{globalFlag, confFlag, replaceFlag, hooksFlag, chainFlag, newChainOrderFlag()},
 		Action: func(ctx *cli.Context) error {
 			confPath := ctx.String("config")
 			isGlobal := ctx.Bool("global")
 			isReplace := ctx.Bool("replace")
 			hooksPath := ctx.String("hookspath")
+			opts := hook.Options{
+				Chain: ctx.Bool("chain"),
+				Order: hook.Order(ctx.String("chain-order")),
+			}
 
-			err := initLint(confPath, hooksPath, isGlobal, isReplace)
+			err := initLint(confPath, hooksPath, isGlobal, isReplace, opts)
 			if handleError(err, "Failed to initialize commitlint") != nil {
 				if isHookExists(err) {
 					fmt.Println("commitlint init failed")
@@ -152,10 +203,41 @@ func newConfigCmd() *cli.Command {
 		},
 	}
 
+	schemaCmd := &cli.Command{
+		Name:  "schema",
+		Usage: "Prints JSON Schema of config, with settings of registered rules",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "write schema to `FILE` instead of stdout",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			return configSchema(ctx.String("output"))
+		},
+	}
+
+	importCmd := &cli.Command{
+		Name:      "import",
+		Usage:     "Converts config of JavaScript commitlint, like .commitlintrc.json or package.json",
+		ArgsUsage: "<file>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "write config to `FILE` instead of stdout",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			return configImport(ctx.Args().Slice(), ctx.String("output"))
+		},
+	}
+
 	return &cli.Command{
 		Name:        "config",
 		Usage:       "Manage commitlint config",
-		Subcommands: []*cli.Command{createCmd, checkCmd},
+		Subcommands: []*cli.Command{createCmd, checkCmd, schemaCmd, importCmd},
 	}
 }
 
@@ -166,11 +248,15 @@ func newHookCmd() *cli.Command {
 	createCmd := &cli.Command{
 		Name:  "create",
 		Usage: "Creates git hook files in current directory",
-		Flags: []cli.Flag{replaceFlag, hooksFlag},
+		Flags: []cli.Flag{replaceFlag, hooksFlag, newChainOrderFlag()},
 		Action: func(ctx *cli.Context) error {
 			isReplace := ctx.Bool("replace")
 			hooksPath := ctx.String("hookspath")
-			err := hookCreate(hooksPath, isReplace)
+			opts := hook.Options{Order: hook.Order(ctx.String("chain-order"))}
+			if !opts.IsValid() {
+				return handleError(errChainOrder, "Failed to create hooks")
+			}
+			err := hookCreate(hooksPath, isReplace, opts)
 			if handleError(err, "Failed to create hooks") != nil {
 				if isHookExists(err) {
 					fmt.Println("create failed. hook files already exist")
@@ -184,10 +270,306 @@ func newHookCmd() *cli.Command {
 		},
 	}
 
+	statusCmd := &cli.Command{
+		Name:  "status",
+		Usage: "Shows installed hooks and hooksPath in local and global git config",
+		Action: func(ctx *cli.Context) error {
+			return hookStatus()
+		},
+	}
+
+	updateCmd := &cli.Command{
+		Name:  "update",
+		Usage: "Updates outdated commitlint hook files",
+		Flags: []cli.Flag{newGlobalFlag("Updates hooks set in global config"), hooksFlag},
+		Action: func(ctx *cli.Context) error {
+			isGlobal := ctx.Bool("global")
+			hooksPath := ctx.String("hookspath")
+			err := hookUpdate(hooksPath, isGlobal)
+			if isHooksNotInstalled(err) {
+				fmt.Println("update failed. commitlint hooks are not installed")
+				fmt.Println("run 'commitlint init' to install hooks")
+				return nil
+			}
+			return handleError(err, "Failed to update hooks")
+		},
+	}
+
 	return &cli.Command{
 		Name:        "hook",
 		Usage:       "Manage commitlint git hooks",
-		Subcommands: []*cli.Command{createCmd},
+		Subcommands: []*cli.Command{createCmd, statusCmd, updateCmd, newUninstallCmd("uninstall")},
+	}
+}
+
+func newDeinitCmd() *cli.Command {
+	cmd := newUninstallCmd("deinit")
+	cmd.Usage = "Remove commitlint hooks from git repos, reverts init"
+	return cmd
+}
+
+func newUninstallCmd(name string) *cli.Command {
+	return &cli.Command{
+		Name:  name,
+		Usage: "Removes commitlint hooks and restores the hooksPath set before init",
+		Flags: []cli.Flag{newGlobalFlag("Removes git hook from global config")},
+		Action: func(ctx *cli.Context) error {
+			isGlobal := ctx.Bool("global")
+			err := deinitLint(isGlobal)
+			if isHooksNotInstalled(err) {
+				fmt.Println("commitlint hooks are not installed")
+				return nil
+			}
+			return handleError(err, "Failed to remove hooks")
+		},
+	}
+}
+
+func newChangelogCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "changelog",
+		Usage: "Generate changelog from conventional commits in git history",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "config",
+				Aliases: []string{"c"},
+				Value:   "",
+				Usage:   "optional config file `conf.yaml`",
+			},
+			&cli.StringFlag{
+				Name:  "from",
+				Value: "",
+				Usage: "start after git `REV`, defaults to latest tag",
+			},
+			&cli.StringFlag{
+				Name:  "to",
+				Value: "HEAD",
+				Usage: "end at git `REV`",
+			},
+			&cli.StringFlag{
+				Name:  "title",
+				Value: "",
+				Usage: "release title like v1.2.0, defaults to --to or 'Unreleased' for HEAD",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: changelogMarkdown,
+				Usage: "output format, markdown or json",
+			},
+			&cli.StringFlag{
+				Name:  "prepend",
+				Value: "",
+				Usage: "prepend the changelog to markdown `FILE` like CHANGELOG.md instead of printing",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			return changelogCmd(
+				ctx.String("config"),
+				ctx.String("from"),
+				ctx.String("to"),
+				ctx.String("title"),
+				ctx.String("format"),
+				ctx.String("prepend"),
+			)
+		},
+	}
+}
+
+func newNextVersionCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "next-version",
+		Usage: "Print next semantic version from commits since the latest version tag",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "config",
+				Aliases: []string{"c"},
+				Value:   "",
+				Usage:   "optional config file `conf.yaml`",
+			},
+			&cli.StringFlag{
+				Name:  "to",
+				Value: "HEAD",
+				Usage: "calculate version at git `REV`",
+			},
+			&cli.StringFlag{
+				Name:  "prerelease",
+				Value: "",
+				Usage: "prerelease `ID` like rc, prints version like v1.2.0-rc.1",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			return nextVersion(ctx.String("config"), ctx.String("to"), ctx.String("prerelease"))
+		},
+	}
+}
+
+func newReportCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "report",
+		Usage: "Lint git history and print compliance statistics",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "config",
+				Aliases: []string{"c"},
+				Value:   "",
+				Usage:   "optional config file `conf.yaml`",
+			},
+			&cli.StringFlag{
+				Name:  "since",
+				Value: "",
+				Usage: "include commits after date `YYYY-MM-DD` or git revision, defaults to whole history",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: reportTable,
+				Usage: "output format, table, json or csv",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			return reportCmd(ctx.String("config"), ctx.String("since"), ctx.String("format"))
+		},
+	}
+}
+
+func newTemplateCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "template",
+		Usage: "Generate git commit template with allowed types, scopes and footers from config",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "config",
+				Aliases: []string{"c"},
+				Value:   "",
+				Usage:   "optional config file `conf.yaml`",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Value:   defaultTemplatePath,
+				Usage:   "template `FILE` to write, '-' prints to stdout",
+			},
+			&cli.BoolFlag{
+				Name:  "git-config",
+				Usage: "sets commit.template in git config to the template file",
+			},
+			newGlobalFlag("sets commit.template in global config"),
+		},
+		Action: func(ctx *cli.Context) error {
+			return templateCmd(ctx.String("config"), ctx.String("output"), ctx.Bool("git-config"), ctx.Bool("global"))
+		},
+	}
+}
+
+func newPrepareCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "prepare",
+		Usage: "Pre-fill commit message with template from config, run by prepare-commit-msg hook",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "config",
+				Aliases: []string{"c"},
+				Value:   "",
+				Usage:   "optional config file `conf.yaml`",
+			},
+			&cli.StringFlag{
+				Name:     "message",
+				Aliases:  []string{"m", "msg"},
+				Value:    "",
+				Usage:    "path to commit message `FILE`",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "source",
+				Value: "",
+				Usage: "`SOURCE` of commit message passed to prepare-commit-msg hook, like message or merge",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			return prepareMsg(ctx.String("config"), ctx.String("message"), ctx.String("source"))
+		},
+	}
+}
+
+func newPreReceiveCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "pre-receive",
+		Usage:     "Lint commits pushed to a server repository, run by pre-receive or update hook",
+		ArgsUsage: "[<ref> <old> <new>]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "config",
+				Aliases: []string{"c"},
+				Value:   "",
+				Usage:   "optional config file `conf.yaml`",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			return preReceive(ctx.String("config"), ctx.Args().Slice())
+		},
+	}
+}
+
+func newPrePushCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "pre-push",
+		Usage:     "Lint commits not yet on the remote, run by pre-push hook",
+		ArgsUsage: "<remote> <url>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "config",
+				Aliases: []string{"c"},
+				Value:   "",
+				Usage:   "optional config file `conf.yaml`",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			return prePush(ctx.String("config"), ctx.Args().Slice())
+		},
+	}
+}
+
+func newServeCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "serve",
+		Usage: "Serve lint requests over HTTP, POST /lint, GET /rules and GET /health",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "config",
+				Aliases: []string{"c"},
+				Value:   "",
+				Usage:   "optional config file `conf.yaml`, used when request has no config",
+			},
+			&cli.StringFlag{
+				Name:  "addr",
+				Value: defaultServeAddr,
+				Usage: "listen `ADDRESS`",
+			},
+			&cli.StringSliceFlag{
+				Name:  "named-config",
+				Usage: "config selected by config_name in request, as `NAME=PATH`, can be repeated",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			return serveCmd(ctx.String("config"), ctx.String("addr"), ctx.StringSlice("named-config"))
+		},
+	}
+}
+
+func newLSPCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "lsp",
+		Usage: "Run language server over stdio for commit message editors",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "config",
+				Aliases: []string{"c"},
+				Value:   "",
+				Usage:   "optional config file `conf.yaml`, defaults to config in workspace root",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			return lspCmd(ctx.String("config"))
+		},
 	}
 }
 
@@ -218,6 +600,22 @@ func newHooksPathFlag() *cli.StringFlag {
 	}
 }
 
+func newGlobalFlag(usage string) *cli.BoolFlag {
+	return &cli.BoolFlag{
+		Name:    "global",
+		Aliases: []string{"g"},
+		Usage:   usage,
+	}
+}
+
+func newChainOrderFlag() *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:  "chain-order",
+		Value: string(hook.OrderBefore),
+		Usage: "Runs the existing hook `before` or `after` commitlint",
+	}
+}
+
 func newReplaceFlag() *cli.BoolFlag {
 	return &cli.BoolFlag{
 		Name:  "replace",
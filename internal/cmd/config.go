@@ -2,12 +2,117 @@ package cmd
 
 import (
 	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/zexot-com/commitlint/config"
+	"github.com/zexot-com/commitlint/internal/changelog"
+	"github.com/zexot-com/commitlint/internal/jsconfig"
+	"github.com/zexot-com/commitlint/internal/push"
+	"github.com/zexot-com/commitlint/internal/release"
+	"github.com/zexot-com/commitlint/lint"
 )
 
+var errImportArgs = errors.New("expected a config file like .commitlintrc.json or package.json")
+
+// commandConfig represent the config of commands like changelog, it is in
+// the same file as lint.Config, but is not used by the linter
+type commandConfig struct {
+	// Changelog config
+	Changelog changelog.Config `yaml:"changelog,omitempty"`
+
+	// Release config for next version calculation
+	Release release.Config `yaml:"release,omitempty"`
+
+	// Receive config for server side pre-receive hook
+	Receive push.Config `yaml:"pre-receive,omitempty"`
+}
+
+// validate checks commandConfig, same as config.Validate for lint.Config
+func (c *commandConfig) validate() []error {
+	var errs []error
+
+	err := release.CheckConfig(c.Release)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	err = push.CheckConfig(c.Receive)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if c.Changelog.ReferencePattern != "" {
+		_, err := regexp.Compile(c.Changelog.ReferencePattern)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid changelog reference pattern: %w", err))
+		}
+	}
+	return errs
+}
+
+// configFileSchema returns the schema of config file with commandConfig
+func configFileSchema() (lint.Schema, error) {
+	s := config.SchemaWith(&commandConfig{})
+
+	actions := []string{push.ActionReject, push.ActionWarn, push.ActionSkip}
+	for _, path := range [][]string{
+		{"pre-receive", "default"},
+		{"pre-receive", "policies", itemsKey, "action"},
+	} {
+		action, err := schemaAt(s, path...)
+		if err != nil {
+			return nil, err
+		}
+		action["enum"] = actions
+	}
+	return s, nil
+}
+
+// itemsKey is used in schemaAt path for the items of array schema
+const itemsKey = "[]"
+
+// schemaAt returns the schema of nested property in path, error if the
+// schema does not have it, like when the config struct is changed
+func schemaAt(s lint.Schema, path ...string) (lint.Schema, error) {
+	for i, key := range path {
+		var sub lint.Schema
+		if key == itemsKey {
+			sub, _ = s["items"].(lint.Schema)
+		} else {
+			props, _ := s["properties"].(map[string]lint.Schema)
+			sub = props[key]
+		}
+		if sub == nil {
+			return nil, fmt.Errorf("config schema has no '%s'", strings.Join(path[:i+1], "."))
+		}
+		s = sub
+	}
+	return s, nil
+}
+
+// parseConfigFile parses the linter and command config in confPath
+func parseConfigFile(confPath string) (*lint.Config, *commandConfig, error) {
+	confBytes, err := os.ReadFile(filepath.Clean(confPath))
+	if err != nil {
+		return nil, nil, fmt.Errorf("config file error: %w", err)
+	}
+
+	cmdConf := &commandConfig{}
+	conf, err := config.DecodeWith(confBytes, cmdConf)
+	if err != nil {
+		return nil, nil, err
+	}
+	conf.Dir = filepath.Dir(filepath.Clean(confPath))
+	return conf, cmdConf, nil
+}
+
 // configCreate is the callback function for create config command
 func configCreate(fileName string, isReplace bool) (retErr error) {
 	outPath := filepath.Join(".", fileName)
@@ -45,9 +150,102 @@ func configCreate(fileName string, isReplace bool) (retErr error) {
 
 // configCheck is the callback function for check/verify command
 func configCheck(confPath string) []error {
-	conf, err := config.Parse(confPath)
+	confBytes, err := os.ReadFile(filepath.Clean(confPath))
+	if handleError(err, "Failed to read configuration file") != nil {
+		return []error{err}
+	}
+
+	// schema errors have the path of invalid value, so they are
+	// reported instead of the same errors from rules
+	s, err := configFileSchema()
+	if handleError(err, "Failed to create config schema") != nil {
+		return []error{err}
+	}
+	errs := config.ValidateSchema(s, confBytes)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	cmdConf := &commandConfig{}
+	conf, err := config.DecodeWith(confBytes, cmdConf)
 	if handleError(err, "Failed to parse configuration file") != nil {
 		return []error{err}
 	}
-	return config.Validate(conf)
+	return append(config.Validate(conf), cmdConf.validate()...)
+}
+
+// configSchema is the callback function for config schema command
+func configSchema(outPath string) (retErr error) {
+	s, err := configFileSchema()
+	if handleError(err, "Failed to create config schema") != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if outPath != "" {
+		f, err := os.Create(filepath.Clean(outPath))
+		if handleError(err, "Failed to create schema file") != nil {
+			return err
+		}
+		defer func() {
+			err := f.Close()
+			if retErr == nil && err != nil {
+				retErr = handleError(err, "Failed to close schema file")
+			}
+		}()
+		w = f
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return handleError(enc.Encode(s), "Failed to write schema")
+}
+
+// configImport is the callback function for config import command
+// converted config is written to outPath or stdout, and rules which could
+// not be converted are reported to stderr
+func configImport(args []string, outPath string) (retErr error) {
+	if len(args) != 1 {
+		return handleError(errImportArgs, "Invalid arguments")
+	}
+
+	data, err := os.ReadFile(filepath.Clean(args[0]))
+	if handleError(err, "Failed to read config file") != nil {
+		return err
+	}
+
+	jsConf, err := jsconfig.Read(args[0], data)
+	if handleError(err, "Failed to parse config file") != nil {
+		return err
+	}
+
+	conf, skipped := jsconfig.Convert(jsConf)
+
+	var w io.Writer = os.Stdout
+	if outPath != "" {
+		f, err := os.Create(filepath.Clean(outPath))
+		if handleError(err, "Failed to create config file") != nil {
+			return err
+		}
+		defer func() {
+			err := f.Close()
+			if retErr == nil && err != nil {
+				retErr = handleError(err, "Failed to close config file")
+			}
+		}()
+		w = f
+	}
+
+	err = config.WriteTo(w, conf)
+	if handleError(err, "Failed to write config") != nil {
+		return err
+	}
+
+	if len(skipped) > 0 {
+		fmt.Fprintf(os.Stderr, "%d rules and fields could not be converted:\n", len(skipped))
+		for _, s := range skipped {
+			fmt.Fprintf(os.Stderr, "  - %s: %s\n", s.Name, s.Reason)
+		}
+	}
+	return nil
 }
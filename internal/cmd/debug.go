@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/zexot-com/commitlint/internal"
+	"github.com/zexot-com/commitlint/internal/git"
 )
 
 func printDebug() error {
@@ -21,12 +22,7 @@ func printDebug() error {
 		return err
 	}
 
-	localConf, err := getGitHookConfig(false)
-	if handleError(err, "Failed to get local Git hook configuration") != nil {
-		return err
-	}
-
-	globalConf, err := getGitHookConfig(true)
+	globalConf, err := git.ScopedConfig(git.ScopeGlobal, hooksPathKey)
 	if handleError(err, "Failed to get global Git hook configuration") != nil {
 		return err
 	}
@@ -40,9 +36,10 @@ func printDebug() error {
 	w.WriteString(gitVer)
 	w.WriteByte('\n')
 
-	w.WriteString("Local Hook: ")
-	w.WriteString(localConf)
-	w.WriteByte('\n')
+	err = writeRepoDebug(w)
+	if handleError(err, "Failed to get repository information") != nil {
+		return err
+	}
 
 	w.WriteString("Global Hook: ")
 	w.WriteString(globalConf)
@@ -76,26 +73,40 @@ func getGitVersion() (string, error) {
 	return ver, nil
 }
 
-func getGitHookConfig(isGlobal bool) (string, error) {
-	b := &bytes.Buffer{}
-
-	var args = []string{"config"}
-	if isGlobal {
-		args = append(args, "--global")
+// writeRepoDebug writes repository paths and local hook config
+// if current directory is inside a git repository
+func writeRepoDebug(w *strings.Builder) error {
+	repo, err := git.OpenRepo(".")
+	if err != nil {
+		w.WriteString("Repository: not a git repository\n")
+		return nil
 	}
-	args = append(args, "core.hooksPath")
-
-	cmd := exec.Command("git", args...)
-	cmd.Stderr = os.Stderr
-	cmd.Stdout = b
 
-	err := cmd.Run()
-	if handleError(err, "Failed to execute 'git config core.hooksPath' command") != nil {
-		return "", err
+	switch {
+	case repo.IsBare:
+		fmt.Fprintf(w, "Repository: %s (bare)\n", repo.CommonDir)
+	case repo.IsLinkedWorktree():
+		fmt.Fprintf(w, "Repository: %s (linked worktree)\n", repo.TopLevel)
+	default:
+		fmt.Fprintf(w, "Repository: %s\n", repo.TopLevel)
+	}
+	fmt.Fprintf(w, "Git Dir: %s\n", repo.GitDir)
+	if repo.IsLinkedWorktree() {
+		fmt.Fprintf(w, "Git Common Dir: %s\n", repo.CommonDir)
 	}
 
-	s := strings.TrimSpace(b.String())
-	s = strings.Trim(s, "\n")
+	hooksDir, err := repo.Path("hooks")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Hooks Dir: %s\n", hooksDir)
 
-	return s, nil
+	localConf, err := git.ScopedConfig(git.ScopeLocal, hooksPathKey)
+	if err != nil {
+		return err
+	}
+	w.WriteString("Local Hook: ")
+	w.WriteString(localConf)
+	w.WriteByte('\n')
+	return nil
 }
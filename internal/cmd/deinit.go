@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/zexot-com/commitlint/internal/git"
+	"github.com/zexot-com/commitlint/internal/hook"
+)
+
+var errHooksNotInstalled = errors.New("commitlint hooks are not installed")
+
+// deinitLint is the callback function for deinit and hook uninstall commands
+// it removes commitlint hooks and restores the hooksPath saved by init
+func deinitLint(isGlobal bool) error {
+	scope := configScope(isGlobal)
+
+	current, err := git.ScopedConfig(scope, hooksPathKey)
+	if handleError(err, "Failed to get hooksPath") != nil {
+		return err
+	}
+
+	hookDir, err := installedHookDir(scope, current)
+	if handleError(err, "Failed to get installed hooks directory") != nil {
+		return err
+	}
+	if hookDir == "" {
+		return errHooksNotInstalled
+	}
+
+	previous, err := git.ScopedConfig(scope, previousHooksPathKey)
+	if handleError(err, "Failed to get previous hooksPath") != nil {
+		return err
+	}
+
+	statuses, err := hook.HookStatus(hookDir)
+	if handleError(err, "Failed to get hook status") != nil {
+		return err
+	}
+
+	// hooksPath changed after init is left unchanged
+	switch {
+	case statuses[0].Options.Chain:
+		// core.hooksPath is not changed in chain mode
+	case current != hookDir:
+		fmt.Printf("core.hooksPath is not set to %s, left unchanged\n", hookDir)
+	case previous != "":
+		err = git.SetConfig(scope, hooksPathKey, previous)
+		if handleError(err, "Failed to restore hooksPath") != nil {
+			return err
+		}
+		fmt.Println("core.hooksPath restored to", previous)
+	default:
+		err = git.UnsetConfig(scope, hooksPathKey)
+		if handleError(err, "Failed to unset hooksPath") != nil {
+			return err
+		}
+		fmt.Println("core.hooksPath unset")
+	}
+
+	for _, key := range []string{installedHooksPathKey, previousHooksPathKey} {
+		err = git.UnsetConfig(scope, key)
+		if handleError(err, "Failed to unset "+key) != nil {
+			return err
+		}
+	}
+
+	removed, err := hook.RemoveHooks(hookDir)
+	if handleError(err, "Failed to remove hooks") != nil {
+		return err
+	}
+	if len(removed) > 0 {
+		fmt.Printf("removed hooks %s from %s\n", strings.Join(removed, ", "), hookDir)
+	}
+	return nil
+}
+
+// installedHookDir returns the hooks dir set by commitlint init in scope
+// hooksPath set by init before it was recorded is detected from the hook files
+func installedHookDir(scope, current string) (string, error) {
+	installed, err := git.ScopedConfig(scope, installedHooksPathKey)
+	if err != nil || installed != "" || current == "" {
+		return installed, err
+	}
+
+	statuses, err := hook.HookStatus(current)
+	if err != nil {
+		return "", err
+	}
+	for _, s := range statuses {
+		if s.IsCommitlint {
+			return current, nil
+		}
+	}
+	return "", nil
+}
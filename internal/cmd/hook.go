@@ -1,12 +1,13 @@
 package cmd
 
 import (
-	"bytes"
 	"errors"
+	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"strings"
 
+	"github.com/zexot-com/commitlint/internal/git"
 	"github.com/zexot-com/commitlint/internal/hook"
 )
 
@@ -17,35 +18,217 @@ const (
 var (
 	errHooksExist  = errors.New("hooks already exists")
 	errConfigExist = errors.New("config file already exists")
+	errFixRange    = errors.New("--fix cannot be used with --range")
+
+	errChainFlags   = errors.New("--chain cannot be used with --global or --hookspath")
+	errChainOrder   = errors.New("--chain-order should be one of [before after]")
+	errHooksPathSet = errors.New("core.hooksPath is set by commitlint init, run 'commitlint deinit' first")
 )
 
 // hookCreate is the callback function for create hook command
-func hookCreate(hooksPath string, isReplace bool) error {
+func hookCreate(hooksPath string, isReplace bool, opts hook.Options) error {
 	if hooksPath == "" {
 		hooksPath = filepath.Join(".", defaultHooksPath)
 	}
 	hooksPath = filepath.Clean(hooksPath)
-	return handleError(createHooks(hooksPath, isReplace), "Failed to create hooks")
+	return handleError(createHooks(hooksPath, isReplace, opts), "Failed to create hooks")
 }
 
-func initHooks(confPath, hookFlag string, isGlobal, isReplace bool) (string, error) {
+// hookStatus is the callback function for hook status command
+func hookStatus() error {
+	w := &strings.Builder{}
+
+	for _, isGlobal := range []bool{false, true} {
+		if isGlobal {
+			w.WriteString("Global:\n")
+		} else {
+			w.WriteString("Local:\n")
+		}
+
+		err := writeHookStatus(w, configScope(isGlobal))
+		if err != nil {
+			if isGlobal {
+				return handleError(err, "Failed to get hook status")
+			}
+			// local config is available only inside a repo
+			w.WriteString("  not a git repository\n")
+		}
+	}
+
+	fmt.Print(w.String())
+	return nil
+}
+
+func writeHookStatus(w *strings.Builder, scope string) error {
+	current, err := git.ScopedConfig(scope, hooksPathKey)
+	if err != nil {
+		return err
+	}
+	installed, err := installedHookDir(scope, current)
+	if err != nil {
+		return err
+	}
+	previous, err := git.ScopedConfig(scope, previousHooksPathKey)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case current == "":
+		w.WriteString("  core.hooksPath: not set\n")
+	case current == installed:
+		fmt.Fprintf(w, "  core.hooksPath: %s (set by commitlint)\n", current)
+	default:
+		fmt.Fprintf(w, "  core.hooksPath: %s\n", current)
+	}
+	if previous != "" {
+		fmt.Fprintf(w, "  previous hooksPath: %s\n", previous)
+	}
+
+	// hooks chained in git hooks dir are listed even if hooksPath is not set
+	hookDir := current
+	if installed != "" && installed != current {
+		hookDir = installed
+		fmt.Fprintf(w, "  hooks: %s (chained by commitlint)\n", installed)
+	}
+	if hookDir == "" {
+		return nil
+	}
+
+	statuses, err := hook.HookStatus(hookDir)
+	if err != nil {
+		return err
+	}
+	for _, s := range statuses {
+		// forwarding hooks are listed only if installed
+		if s.Forward && !s.Exists {
+			continue
+		}
+		fmt.Fprintf(w, "  %s: %s\n", s.Name, hookState(s))
+	}
+	return nil
+}
+
+func hookState(s hook.Status) string {
+	switch {
+	case !s.Exists:
+		return "not installed"
+	case !s.IsCommitlint:
+		return "not a commitlint hook"
+	}
+
+	state := "installed"
+	if s.Forward {
+		state = "forwarded to previous hooksPath"
+	} else if s.Version > 0 {
+		state += " (" + s.Options.String() + ")"
+	}
+	if !s.Executable {
+		state += ", not executable"
+	}
+	if s.IsCurrent() {
+		return state + ", up to date"
+	}
+	return state + fmt.Sprintf(", outdated (version %d, current %d)", s.Version, hook.Version)
+}
+
+// hookUpdate is the callback function for hook update command
+func hookUpdate(hooksPath string, isGlobal bool) error {
+	hookDir := hooksPath
+	if hookDir == "" {
+		scope := configScope(isGlobal)
+		current, err := git.ScopedConfig(scope, hooksPathKey)
+		if handleError(err, "Failed to get hooksPath") != nil {
+			return err
+		}
+		hookDir, err = installedHookDir(scope, current)
+		if handleError(err, "Failed to get installed hooks directory") != nil {
+			return err
+		}
+		if hookDir == "" {
+			return errHooksNotInstalled
+		}
+	}
+
+	updated, err := hook.UpdateHooks(hookDir)
+	if handleError(err, "Failed to update hooks") != nil {
+		return err
+	}
+
+	if len(updated) == 0 {
+		fmt.Println("hooks are up to date")
+		return nil
+	}
+	fmt.Printf("updated hooks %s in %s\n", strings.Join(updated, ", "), hookDir)
+	return nil
+}
+
+func initHooks(confPath, hookFlag string, isGlobal, isReplace bool, opts hook.Options) (string, error) {
+	if opts.Chain {
+		return chainHooks(isReplace, opts)
+	}
+
 	hookDir, err := getHookDir(hookFlag, isGlobal)
 	if handleError(err, "Failed to get hook directory") != nil {
 		return "", err
 	}
 
-	err = writeHooks(hookDir, isReplace)
+	err = writeHooks(hookDir, isReplace, opts)
 	if handleError(err, "Failed to write hooks") != nil {
 		return "", err
 	}
 	return hookDir, nil
 }
 
-func createHooks(hookBaseDir string, isReplace bool) error {
-	return handleError(writeHooks(hookBaseDir, isReplace), "Failed to write hooks to base directory")
+// chainHooks writes commitlint hooks like commit-msg and pre-push to the hooks
+// dir used by git, existing hooks of same name are kept and run by commitlint hooks
+func chainHooks(isReplace bool, opts hook.Options) (string, error) {
+	current, err := git.ScopedConfig(git.ScopeLocal, hooksPathKey)
+	if handleError(err, "Failed to get hooksPath") != nil {
+		return "", err
+	}
+	installed, err := git.ScopedConfig(git.ScopeLocal, installedHooksPathKey)
+	if handleError(err, "Failed to get installed hooks directory") != nil {
+		return "", err
+	}
+	if current != "" && current == installed {
+		return "", handleError(errHooksPathSet, "Failed to chain hooks")
+	}
+
+	repo, err := git.OpenRepo(".")
+	if handleError(err, "Failed to open git repository") != nil {
+		return "", err
+	}
+	hookDir, err := repo.Path("hooks")
+	if handleError(err, "Failed to get git hooks directory") != nil {
+		return "", err
+	}
+
+	statuses, err := hook.HookStatus(hookDir)
+	if handleError(err, "Failed to get hook status") != nil {
+		return "", err
+	}
+	if statuses[0].IsCommitlint && !isReplace {
+		return "", handleError(errHooksExist, "Hook already exists and replace option not set")
+	}
+
+	err = os.MkdirAll(hookDir, os.ModePerm)
+	if handleError(err, "Failed to create hook directory") != nil {
+		return "", err
+	}
+
+	err = hook.WriteHooks(hookDir, opts)
+	if handleError(err, "Failed to write hooks to directory") != nil {
+		return "", err
+	}
+	return hookDir, nil
+}
+
+func createHooks(hookBaseDir string, isReplace bool, opts hook.Options) error {
+	return handleError(writeHooks(hookBaseDir, isReplace, opts), "Failed to write hooks to base directory")
 }
 
-func writeHooks(hookDir string, isReplace bool) error {
+func writeHooks(hookDir string, isReplace bool, opts hook.Options) error {
 	// if commit-msg already exists skip creating or overwriting it
 	if _, err := os.Stat(hookDir); !os.IsNotExist(err) {
 		if !isReplace {
@@ -59,7 +242,7 @@ func writeHooks(hookDir string, isReplace bool) error {
 	}
 
 	// create hook file
-	return handleError(hook.WriteHooks(hookDir), "Failed to write hooks to directory")
+	return handleError(hook.WriteHooks(hookDir, opts), "Failed to write hooks to directory")
 }
 
 func getHookDir(hookFlag string, isGlobal bool) (string, error) {
@@ -85,37 +268,44 @@ func getHookDir(hookFlag string, isGlobal bool) (string, error) {
 		return hookDir, nil
 	}
 
-	gitDir, err := getRepoRootDir()
+	repoDir, err := getRepoRootDir()
 	if handleError(err, "Failed to get repository root directory") != nil {
 		return "", err
 	}
-	return filepath.Join(gitDir, hookFlag), nil
+	return filepath.Join(repoDir, hookFlag), nil
 }
 
+// getRepoRootDir returns the root of main working tree, so that hooks are
+// shared by linked worktrees. For bare repository git dir is returned
 func getRepoRootDir() (string, error) {
-	byteOut := &bytes.Buffer{}
-
-	cmd := exec.Command("git", "rev-parse", "--git-dir")
-	cmd.Stdout = byteOut
-	cmd.Stderr = os.Stderr
-
-	err := cmd.Run()
-	if handleError(err, "Failed to get repository root directory with git command") != nil {
+	repo, err := git.OpenRepo(".")
+	if handleError(err, "Failed to open git repository") != nil {
 		return "", err
 	}
 
-	gitDir := filepath.Clean(byteOut.String())
-
-	// remove /.git at last
-	gitDir = filepath.Dir(gitDir)
+	if repo.IsBare {
+		return repo.CommonDir, nil
+	}
 
-	return gitDir, nil
+	topLevel, err := repo.MainTopLevel()
+	if handleError(err, "Failed to get main working tree") != nil {
+		return "", err
+	}
+	if topLevel == "" {
+		// linked worktree of a bare repository
+		return repo.CommonDir, nil
+	}
+	return topLevel, nil
 }
 
 func isHookExists(err error) bool {
 	return errors.Is(err, errHooksExist)
 }
 
+func isHooksNotInstalled(err error) bool {
+	return errors.Is(err, errHooksNotInstalled)
+}
+
 func isConfExists(err error) bool {
 	return errors.Is(err, errConfigExist)
 }
@@ -1,27 +1,85 @@
 package cmd
 
 import (
-	"os"
-	"os/exec"
+	"github.com/zexot-com/commitlint/internal/git"
+	"github.com/zexot-com/commitlint/internal/hook"
+)
+
+// git config keys used to manage hooksPath
+const (
+	hooksPathKey = "core.hooksPath"
+
+	// installedHooksPathKey records the hooks dir set by commitlint init
+	installedHooksPathKey = "commitlint.hooksPath"
+
+	// previousHooksPathKey records core.hooksPath before commitlint init
+	// so that deinit can restore it
+	previousHooksPathKey = "commitlint.previousHooksPath"
 )
 
 // initLint is the callback function for the init command
-func initLint(confPath, hooksPath string, isGlobal, isReplace bool) error {
-	hookDir, err := initHooks(confPath, hooksPath, isGlobal, isReplace)
+func initLint(confPath, hooksPath string, isGlobal, isReplace bool, opts hook.Options) error {
+	if !opts.IsValid() {
+		return handleError(errChainOrder, "Failed to initialize hooks")
+	}
+	if opts.Chain && (isGlobal || hooksPath != "") {
+		return handleError(errChainFlags, "Failed to initialize hooks")
+	}
+
+	hookDir, err := initHooks(confPath, hooksPath, isGlobal, isReplace, opts)
 	if handleError(err, "Failed to initialize hooks") != nil {
 		return err
 	}
+
+	// core.hooksPath is not changed in chain mode
+	if opts.Chain {
+		err = git.SetConfig(git.ScopeLocal, installedHooksPathKey, hookDir)
+		return handleError(err, "Failed to set git configuration")
+	}
 	return handleError(setGitConf(hookDir, isGlobal), "Failed to set git configuration")
 }
 
 func setGitConf(hookDir string, isGlobal bool) error {
-	args := []string{"config"}
-	if isGlobal {
-		args = append(args, "--global")
+	scope := configScope(isGlobal)
+
+	err := saveHooksPath(scope, hookDir)
+	if handleError(err, "Failed to save current hooksPath") != nil {
+		return err
 	}
-	args = append(args, "core.hooksPath", hookDir)
 
-	cmd := exec.Command("git", args...)
-	cmd.Stderr = os.Stderr
-	return handleError(cmd.Run(), "Failed to execute git config command")
+	err = git.SetConfig(scope, hooksPathKey, hookDir)
+	if handleError(err, "Failed to execute git config command") != nil {
+		return err
+	}
+	return handleError(git.SetConfig(scope, installedHooksPathKey, hookDir), "Failed to execute git config command")
+}
+
+// saveHooksPath records current core.hooksPath as previous hooksPath
+// running init again keeps the hooksPath saved by first init
+func saveHooksPath(scope, hookDir string) error {
+	current, err := git.ScopedConfig(scope, hooksPathKey)
+	if err != nil {
+		return err
+	}
+
+	installed, err := git.ScopedConfig(scope, installedHooksPathKey)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case current == hookDir || (installed != "" && current == installed):
+		return nil
+	case current == "":
+		return git.UnsetConfig(scope, previousHooksPathKey)
+	default:
+		return git.SetConfig(scope, previousHooksPathKey, current)
+	}
+}
+
+func configScope(isGlobal bool) string {
+	if isGlobal {
+		return git.ScopeGlobal
+	}
+	return git.ScopeLocal
 }
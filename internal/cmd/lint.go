@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	"github.com/zexot-com/commitlint/config"
+	"github.com/zexot-com/commitlint/internal"
+	"github.com/zexot-com/commitlint/internal/git"
 	"github.com/zexot-com/commitlint/lint"
 	"github.com/urfave/cli/v2"
 )
@@ -18,9 +20,9 @@ const (
 )
 
 // lintMsg is the callback function for lint command
-func lintMsg(confPath, msgPath string) error {
+func lintMsg(confPath, msgPath, cleanup string, isFix bool) error {
 	// NOTE: lint should return with exit code for error case
-	resStr, hasError, err := runLint(confPath, msgPath)
+	resStr, hasError, err := runLint(confPath, msgPath, cleanup, isFix)
 	if handleError(err, "Linting failed") != nil {
 		return err
 	}
@@ -34,17 +36,24 @@ func lintMsg(confPath, msgPath string) error {
 	return nil
 }
 
-func runLint(confFilePath, fileInput string) (lintResult string, hasError bool, err error) {
-	linter, format, err := getLinter(confFilePath)
+func runLint(confFilePath, fileInput, cleanup string, isFix bool) (lintResult string, hasError bool, err error) {
+	linter, format, err := getLinter(confFilePath, lint.WithEnvironment(commitEnvironment()))
 	if handleError(err, "Failed to create linter") != nil {
 		return "", false, err
 	}
 
-	commitMsg, err := getCommitMsg(fileInput)
+	commitMsg, msgPath, err := getCommitMsg(fileInput, cleanup)
 	if handleError(err, "Failed to read commit message") != nil {
 		return "", false, err
 	}
 
+	if isFix {
+		commitMsg, err = fixCommitMsg(linter, commitMsg, msgPath)
+		if handleError(err, "Fixing commit message failed") != nil {
+			return "", false, err
+		}
+	}
+
 	result, err := linter.ParseAndLint(commitMsg)
 	if handleError(err, "Linting process failed") != nil {
 		return "", false, err
@@ -58,7 +67,96 @@ func runLint(confFilePath, fileInput string) (lintResult string, hasError bool,
 	return output, hasErrorSeverity(result), nil
 }
 
-func getLinter(confParam string) (*lint.Linter, lint.Formatter, error) {
+// lintRange is the callback function for lint command with range
+func lintRange(confPath, revRange string) error {
+	resStr, hasError, err := runLintRange(confPath, revRange)
+	if handleError(err, "Linting failed") != nil {
+		return err
+	}
+
+	if hasError {
+		return cli.Exit(resStr, errExitCode)
+	}
+
+	fmt.Println(resStr)
+	return nil
+}
+
+func runLintRange(confFilePath, revRange string) (lintResult string, hasError bool, err error) {
+	linter, format, err := getLinter(confFilePath)
+	if handleError(err, "Failed to create linter") != nil {
+		return "", false, err
+	}
+
+	commits, err := git.Log(revRange)
+	if handleError(err, "Failed to read commits in range") != nil {
+		return "", false, err
+	}
+
+	return lintCommits(linter, format, commits)
+}
+
+// lintCommits lints given commits and returns the formatted results of all commits
+func lintCommits(linter *lint.Linter, format lint.Formatter, commits []*git.Commit) (lintResult string, hasError bool, err error) {
+	if len(commits) == 0 {
+		return "no commits to lint", false, nil
+	}
+
+	results := make([]lint.BatchResult, 0, len(commits))
+	for _, commit := range commits {
+		author := lint.Signature{Name: commit.AuthorName, Email: commit.AuthorEmail}
+		result, err := linter.ParseAndLintAuthored(commit.Message, author)
+		if handleError(err, "Linting process failed") != nil {
+			return "", false, err
+		}
+
+		results = append(results, lint.BatchResult{Kind: "commit", ID: commit.ShortHash(), Result: result})
+		hasError = hasError || hasErrorSeverity(result)
+	}
+
+	output, err := formatBatch(format, results)
+	if handleError(err, "Formatting result failed") != nil {
+		return "", false, err
+	}
+	return output, hasError, nil
+}
+
+// formatBatch formats results of many commits, formatters which do not
+// implement lint.BatchFormatter format each result after a header line
+func formatBatch(format lint.Formatter, results []lint.BatchResult) (string, error) {
+	if batch, ok := format.(lint.BatchFormatter); ok {
+		return batch.FormatBatch(results)
+	}
+
+	outputs := make([]string, 0, len(results))
+	for _, r := range results {
+		output, err := format.Format(r.Result)
+		if err != nil {
+			return "", err
+		}
+		outputs = append(outputs, r.Kind+" "+r.ID+"\n"+output)
+	}
+	return strings.Join(outputs, "\n\n"), nil
+}
+
+// commitEnvironment returns the environment of commit being made in current repository
+func commitEnvironment() lint.Environment {
+	var env lint.Environment
+	env.Branch, _ = git.CurrentBranch()
+	env.Identity = commitIdentity()
+	return env
+}
+
+// commitIdentity returns the committer identity from git config, empty if not set
+func commitIdentity() lint.Signature {
+	name, email, err := git.Identity()
+	if err != nil {
+		return lint.Signature{}
+	}
+	return lint.Signature{Name: name, Email: email}
+}
+
+func getLinter(confParam string, opts ...lint.Option) (*lint.Linter, lint.Formatter, error) {
 	conf, err := getConfig(confParam)
 	if handleError(err, "Failed to get configuration") != nil {
 		return nil, nil, err
@@ -69,7 +167,7 @@ func getLinter(confParam string) (*lint.Linter, lint.Formatter, error) {
 		return nil, nil, err
 	}
 
-	linter, err := config.NewLinter(conf)
+	linter, err := config.NewLinter(conf, opts...)
 	if handleError(err, "Failed to create new linter") != nil {
 		return nil, nil, err
 	}
@@ -78,41 +176,116 @@ func getLinter(confParam string) (*lint.Linter, lint.Formatter, error) {
 }
 
 func getConfig(confParam string) (*lint.Config, error) {
+	conf, _, err := getCommandConfig(confParam)
+	return conf, err
+}
+
+// getCommandConfig returns the linter and command config in confParam
+// or in the config file found by lookup
+func getCommandConfig(confParam string) (*lint.Config, *commandConfig, error) {
 	if confParam != "" {
-		confParam = filepath.Clean(confParam)
-		return config.Parse(confParam)
+		return parseConfigFile(confParam)
 	}
 
 	// If config param is empty, lookup for defaults
-	conf, err := config.LookupAndParse()
+	confPath, confType, err := internal.LookupConfigPath()
 	if handleError(err, "Failed to lookup and parse configuration") != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if confType == internal.DefaultConfig {
+		return config.NewDefault(), &commandConfig{}, nil
 	}
 
-	return conf, nil
+	conf, cmdConf, err := parseConfigFile(confPath)
+	if handleError(err, "Failed to lookup and parse configuration") != nil {
+		return nil, nil, err
+	}
+	return conf, cmdConf, nil
 }
 
-func getCommitMsg(fileInput string) (string, error) {
-	commitMsg, err := readStdInPipe()
-	if handleError(err, "Failed to read commit message from stdin") != nil {
+// fixCommitMsg applies fixes to commitMsg and writes the fixed message
+// back to msgPath, or prints it when message is read from stdin
+func fixCommitMsg(linter *lint.Linter, commitMsg, msgPath string) (string, error) {
+	fixedMsg, err := linter.Fix(commitMsg)
+	if handleError(err, "Failed to apply fixes") != nil {
 		return "", err
 	}
 
-	if commitMsg != "" {
+	if fixedMsg == commitMsg {
 		return commitMsg, nil
 	}
 
-	// TODO: check if currentDir is inside git repo?
+	if msgPath == "" {
+		fmt.Println(fixedMsg)
+		return fixedMsg, nil
+	}
+
+	err = os.WriteFile(msgPath, []byte(fixedMsg), 0600)
+	if handleError(err, "Failed to write fixed commit message file") != nil {
+		return "", err
+	}
+	return fixedMsg, nil
+}
+
+// getCommitMsg returns the commit message and path of the message file
+// path is empty if message is read from stdin. Message file is cleaned up
+// as git does before commit, with cleanup mode or commit.cleanup config
+func getCommitMsg(fileInput, cleanup string) (commitMsg, msgPath string, err error) {
+	commitMsg, err = readStdInPipe()
+	if handleError(err, "Failed to read commit message from stdin") != nil {
+		return "", "", err
+	}
+
+	if commitMsg != "" {
+		return commitMsg, "", nil
+	}
+
 	if fileInput == "" {
-		fileInput = "./.git/COMMIT_EDITMSG"
+		fileInput, err = defaultCommitMsgPath()
+		if handleError(err, "Failed to get commit message file") != nil {
+			return "", "", err
+		}
 	}
 
 	fileInput = filepath.Clean(fileInput)
 	inBytes, err := os.ReadFile(fileInput)
 	if handleError(err, "Failed to read commit message file") != nil {
+		return "", "", err
+	}
+
+	commitMsg, err = cleanupCommitMsg(string(inBytes), cleanup)
+	if handleError(err, "Failed to cleanup commit message") != nil {
+		return "", "", err
+	}
+	return commitMsg, fileInput, nil
+}
+
+// cleanupCommitMsg removes comments, scissors and diff from commit message
+// file using core.commentChar. mode overrides commit.cleanup config
+func cleanupCommitMsg(msg, mode string) (string, error) {
+	var err error
+	if mode == "" {
+		mode, err = git.CleanupMode()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	commentChar, err := git.CommentChar(msg)
+	if err != nil {
+		return "", err
+	}
+	mode = git.ResolveCleanupMode(mode, git.IsEditorUsed())
+	return git.CleanupMessage(msg, mode, commentChar)
+}
+
+// defaultCommitMsgPath returns COMMIT_EDITMSG of current working tree
+func defaultCommitMsgPath() (string, error) {
+	repo, err := git.OpenRepo(".")
+	if err != nil {
 		return "", err
 	}
-	return string(inBytes), nil
+	return repo.Path("COMMIT_EDITMSG")
 }
 
 func readStdInPipe() (string, error) {
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/zexot-com/commitlint/config"
+	"github.com/zexot-com/commitlint/internal"
+	"github.com/zexot-com/commitlint/internal/lsp"
+	"github.com/zexot-com/commitlint/lint"
+)
+
+// lspCmd is the callback function for lsp command
+// it serves language server protocol over stdin and stdout
+func lspCmd(confPath string) error {
+	if confPath != "" {
+		// server changes directory to workspace root
+		absPath, err := filepath.Abs(confPath)
+		if handleError(err, "Failed to get config path") != nil {
+			return err
+		}
+		confPath = absPath
+	}
+
+	load := func() (*lint.Config, string, error) {
+		return lspConfig(confPath)
+	}
+
+	// documents are messages of new commits, branch is not set as
+	// commits of other branches can be edited, like in rebase
+	env := lint.Environment{Identity: commitIdentity()}
+
+	err := lsp.NewServer(os.Stdin, os.Stdout, load, lint.WithEnvironment(env)).Run()
+	return handleError(err, "Language server failed")
+}
+
+// lspConfig returns the config and its path, config is looked up
+// if confPath is empty. path is empty for default config
+func lspConfig(confPath string) (*lint.Config, string, error) {
+	if confPath == "" {
+		path, typ, err := internal.LookupConfigPath()
+		if err != nil {
+			return nil, "", err
+		}
+		if typ == internal.DefaultConfig {
+			return config.NewDefault(), "", nil
+		}
+		confPath = path
+	}
+
+	// command configs in the file are not used by linter
+	conf, _, err := parseConfigFile(confPath)
+	return conf, confPath, err
+}
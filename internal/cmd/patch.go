@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/zexot-com/commitlint/internal/patch"
+	"github.com/zexot-com/commitlint/lint"
+)
+
+// stdinPatchName is the file name of patches read from stdin
+const stdinPatchName = "stdin"
+
+var (
+	errPatchFlags = errors.New("--patch cannot be used with --message, --range or --fix")
+	errNoPatch    = errors.New("no patch files given and no patches in stdin")
+)
+
+// lintPatches lints the commit messages in patch and mbox files
+func lintPatches(confPath string, files []string) error {
+	resStr, hasError, err := runLintPatches(confPath, files)
+	if handleError(err, "Linting failed") != nil {
+		return err
+	}
+
+	if hasError {
+		return cli.Exit(resStr, errExitCode)
+	}
+
+	fmt.Println(resStr)
+	return nil
+}
+
+func runLintPatches(confFilePath string, files []string) (lintResult string, hasError bool, err error) {
+	patches, err := readPatches(files)
+	if handleError(err, "Failed to read patches") != nil {
+		return "", false, err
+	}
+
+	linter, format, err := getLinter(confFilePath)
+	if handleError(err, "Failed to create linter") != nil {
+		return "", false, err
+	}
+
+	results := make([]lint.BatchResult, 0, len(patches))
+	for _, p := range patches {
+		author := lint.Signature{Name: p.AuthorName, Email: p.AuthorEmail}
+		result, err := linter.ParseAndLintAuthored(p.Message, author)
+		if handleError(err, "Linting process failed") != nil {
+			return "", false, err
+		}
+
+		results = append(results, lint.BatchResult{Kind: "patch", ID: p.Key(), Result: result})
+		hasError = hasError || hasErrorSeverity(result)
+	}
+
+	output, err := formatBatch(format, results)
+	if handleError(err, "Formatting result failed") != nil {
+		return "", false, err
+	}
+	return output, hasError, nil
+}
+
+// readPatches returns patches of all files in order, stdin is read if
+// files are not given
+func readPatches(files []string) ([]*patch.Patch, error) {
+	if len(files) == 0 {
+		input, err := readStdInPipe()
+		if err != nil {
+			return nil, err
+		}
+		if input == "" {
+			return nil, errNoPatch
+		}
+		return patch.Parse(stdinPatchName, strings.NewReader(input))
+	}
+
+	var patches []*patch.Patch
+	for _, file := range files {
+		f, err := os.Open(filepath.Clean(file))
+		if err != nil {
+			return nil, err
+		}
+
+		filePatches, err := patch.Parse(file, f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		patches = append(patches, filePatches...)
+	}
+	return patches, nil
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/zexot-com/commitlint/internal/git"
+	"github.com/zexot-com/commitlint/internal/push"
+)
+
+var errPushArgs = errors.New("expected '<remote> <url>' arguments of pre-push hook")
+
+// prePush is the callback function for pre-push command
+// pushed refs are read from stdin, args are the remote name and url
+func prePush(confPath string, args []string) error {
+	output, isRejected, err := runPrePush(confPath, args)
+	if err != nil {
+		return err
+	}
+
+	if isRejected {
+		return cli.Exit(output, errExitCode)
+	}
+
+	fmt.Print(output)
+	return nil
+}
+
+func runPrePush(confPath string, args []string) (string, bool, error) {
+	if len(args) > 2 {
+		return "", false, handleError(errPushArgs, "Invalid arguments")
+	}
+
+	// remote is empty when pushing to url, commits are then
+	// checked against tracking refs of all remotes
+	var remote string
+	if len(args) == 2 && args[0] != args[1] {
+		remote = args[0]
+	}
+
+	updates, err := push.ParsePush(os.Stdin)
+	if handleError(err, "Failed to read pushed refs") != nil {
+		return "", false, err
+	}
+
+	linter, _, err := getLinter(confPath)
+	if err != nil {
+		return "", false, err
+	}
+
+	var results []*push.RefResult
+	isRejected := false
+	for _, u := range updates {
+		commits, err := push.OutgoingCommits(u, remote, git.Log)
+		if handleError(err, "Failed to read outgoing commits") != nil {
+			return "", false, err
+		}
+
+		res, err := push.Lint(linter, u.Ref, push.ActionReject, commits)
+		if handleError(err, "Failed to lint outgoing commits") != nil {
+			return "", false, err
+		}
+		isRejected = isRejected || res.IsRejected()
+		results = append(results, res)
+	}
+	return push.Format(results), isRejected, nil
+}
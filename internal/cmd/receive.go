@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/zexot-com/commitlint/config"
+	"github.com/zexot-com/commitlint/internal/git"
+	"github.com/zexot-com/commitlint/internal/push"
+)
+
+var errReceiveArgs = errors.New("expected no arguments for pre-receive hook or '<ref> <old> <new>' for update hook")
+
+// preReceive is the callback function for pre-receive command
+// updates are read from stdin, or from args when run as update hook
+func preReceive(confPath string, args []string) error {
+	output, isRejected, err := runPreReceive(confPath, args)
+	if err != nil {
+		return err
+	}
+
+	if isRejected {
+		return cli.Exit(output, errExitCode)
+	}
+
+	fmt.Print(output)
+	return nil
+}
+
+func runPreReceive(confPath string, args []string) (string, bool, error) {
+	updates, err := receiveUpdates(args)
+	if handleError(err, "Failed to read ref updates") != nil {
+		return "", false, err
+	}
+
+	conf, cmdConf, err := getCommandConfig(confPath)
+	if handleError(err, "Failed to get configuration") != nil {
+		return "", false, err
+	}
+
+	linter, err := config.NewLinter(conf)
+	if handleError(err, "Failed to create new linter") != nil {
+		return "", false, err
+	}
+
+	var results []*push.RefResult
+	isRejected := false
+	for _, u := range updates {
+		action := push.Action(cmdConf.Receive, u.Ref)
+		if action == push.ActionSkip {
+			continue
+		}
+
+		commits, err := push.NewCommits(u, git.Log)
+		if handleError(err, "Failed to read pushed commits") != nil {
+			return "", false, err
+		}
+
+		res, err := push.Lint(linter, u.Ref, action, commits)
+		if handleError(err, "Failed to lint pushed commits") != nil {
+			return "", false, err
+		}
+		isRejected = isRejected || res.IsRejected()
+		results = append(results, res)
+	}
+	return push.Format(results), isRejected, nil
+}
+
+func receiveUpdates(args []string) ([]push.Update, error) {
+	switch len(args) {
+	case 0:
+		return push.ParseReceive(os.Stdin)
+	case 3:
+		return []push.Update{{Ref: args[0], Old: args[1], New: args[2]}}, nil
+	}
+	return nil, errReceiveArgs
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/zexot-com/commitlint/config"
+	"github.com/zexot-com/commitlint/internal/git"
+	"github.com/zexot-com/commitlint/internal/release"
+)
+
+const (
+	// noReleaseExitCode is the exit code when commits does not need a release
+	noReleaseExitCode = 3
+)
+
+// nextVersion is the callback function for next-version command
+func nextVersion(confPath, to, prerelease string) error {
+	version, isRelease, err := runNextVersion(confPath, to, prerelease)
+	if handleError(err, "Failed to calculate next version") != nil {
+		return err
+	}
+
+	if !isRelease {
+		return cli.Exit("no release needed", noReleaseExitCode)
+	}
+
+	fmt.Println(version)
+	return nil
+}
+
+func runNextVersion(confPath, to, prerelease string) (string, bool, error) {
+	conf, cmdConf, err := getCommandConfig(confPath)
+	if handleError(err, "Failed to get configuration") != nil {
+		return "", false, err
+	}
+
+	linter, err := config.NewLinter(conf)
+	if handleError(err, "Failed to create new linter") != nil {
+		return "", false, err
+	}
+
+	calc, err := release.New(linter, cmdConf.Release)
+	if handleError(err, "Failed to create version calculator") != nil {
+		return "", false, err
+	}
+
+	tags, err := git.Tags(to)
+	if handleError(err, "Failed to read tags") != nil {
+		return "", false, err
+	}
+
+	commitsSince := func(tag string) ([]*git.Commit, error) {
+		if tag == "" {
+			return git.Log(to)
+		}
+		return git.Log(tag + ".." + to)
+	}
+
+	return calc.Next(tags, commitsSince, prerelease)
+}
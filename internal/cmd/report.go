@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zexot-com/commitlint/config"
+	"github.com/zexot-com/commitlint/internal/git"
+	"github.com/zexot-com/commitlint/internal/report"
+)
+
+// report output formats
+const (
+	reportTable = "table"
+	reportJSON  = "json"
+	reportCSV   = "csv"
+)
+
+var errReportFormat = errors.New("unknown report format, should be one of [table json csv]")
+
+// reportCmd is the callback function for report command
+func reportCmd(confPath, since, format string) error {
+	if format != reportTable && format != reportJSON && format != reportCSV {
+		return handleError(errReportFormat, "Invalid report format")
+	}
+
+	output, err := runReport(confPath, since, format)
+	if handleError(err, "Failed to generate report") != nil {
+		return err
+	}
+
+	fmt.Print(output)
+	return nil
+}
+
+func runReport(confPath, since, format string) (string, error) {
+	conf, err := getConfig(confPath)
+	if handleError(err, "Failed to get configuration") != nil {
+		return "", err
+	}
+
+	linter, err := config.NewLinter(conf)
+	if handleError(err, "Failed to create new linter") != nil {
+		return "", err
+	}
+
+	commits, err := git.Log(sinceArgs(since)...)
+	if handleError(err, "Failed to read commits") != nil {
+		return "", err
+	}
+
+	rep, err := report.Generate(linter, commits)
+	if handleError(err, "Failed to lint commits") != nil {
+		return "", err
+	}
+
+	switch format {
+	case reportJSON:
+		out, err := json.MarshalIndent(rep, "", "  ")
+		if handleError(err, "Failed to format report") != nil {
+			return "", err
+		}
+		return string(out) + "\n", nil
+	case reportCSV:
+		out, err := rep.CSV()
+		if handleError(err, "Failed to format report") != nil {
+			return "", err
+		}
+		return out, nil
+	default:
+		return rep.Table(), nil
+	}
+}
+
+// sinceArgs returns git log args for since, which is a date like
+// 2024-01-31 or a revision. Empty since selects the whole history
+func sinceArgs(since string) []string {
+	if since == "" {
+		return []string{"HEAD"}
+	}
+	if _, err := time.Parse("2006-01-02", since); err == nil {
+		return []string{"--since=" + since, "HEAD"}
+	}
+	return []string{since + "..HEAD"}
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/zexot-com/commitlint/config"
+	"github.com/zexot-com/commitlint/server"
+)
+
+// defaultServeAddr is the listen address of serve command
+const defaultServeAddr = "localhost:8080"
+
+var errNamedConfig = errors.New("--named-config should be in 'name=path' format")
+
+// serveCmd is the callback function for serve command
+func serveCmd(confPath, addr string, namedConfs []string) error {
+	conf, err := getConfig(confPath)
+	if handleError(err, "Failed to get configuration") != nil {
+		return err
+	}
+
+	// check the default config before serving requests
+	_, err = config.NewLinter(conf)
+	if handleError(err, "Failed to create new linter") != nil {
+		return err
+	}
+
+	opts := make([]server.Option, 0, len(namedConfs))
+	for _, named := range namedConfs {
+		name, path, ok := strings.Cut(named, "=")
+		if !ok || name == "" || path == "" {
+			return handleError(errNamedConfig, "Invalid named config")
+		}
+
+		namedConf, _, err := parseConfigFile(path)
+		if handleError(err, "Failed to parse config "+name) != nil {
+			return err
+		}
+		opts = append(opts, server.WithConfig(name, namedConf))
+	}
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           server.NewHandler(conf, opts...),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(os.Stderr, "commitlint serving on %s\n", addr)
+	err = srv.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return handleError(err, "Failed to serve")
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/zexot-com/commitlint/config"
+	"github.com/zexot-com/commitlint/internal/git"
+	"github.com/zexot-com/commitlint/internal/scaffold"
+)
+
+const (
+	defaultTemplatePath = ".gitmessage"
+
+	// stdoutPath prints the template instead of writing to file
+	stdoutPath = "-"
+)
+
+var errTemplateStdout = errors.New("--git-config cannot be used when template is printed to stdout")
+
+// templateCmd is the callback function for template command
+func templateCmd(confPath, output string, isGitConfig, isGlobal bool) error {
+	if output == stdoutPath && isGitConfig {
+		return handleError(errTemplateStdout, "Failed to create commit template")
+	}
+
+	s, err := getScaffold(confPath)
+	if handleError(err, "Failed to create commit template") != nil {
+		return err
+	}
+
+	s.CommentChar, err = git.CommentChar("")
+	if handleError(err, "Failed to get comment char") != nil {
+		return err
+	}
+
+	if output == stdoutPath {
+		fmt.Print(s.Template())
+		return nil
+	}
+
+	err = os.WriteFile(output, []byte(s.Template()), 0644)
+	if handleError(err, "Failed to write commit template") != nil {
+		return err
+	}
+	fmt.Println("commit template written to", output)
+
+	if !isGitConfig {
+		return nil
+	}
+
+	absPath, err := filepath.Abs(output)
+	if handleError(err, "Failed to get absolute path for commit template") != nil {
+		return err
+	}
+	err = git.SetConfig(configScope(isGlobal), "commit.template", absPath)
+	if handleError(err, "Failed to set commit.template") != nil {
+		return err
+	}
+	fmt.Println("commit.template set to", absPath)
+	return nil
+}
+
+// prepareMsg is the callback function for prepare command
+// run by prepare-commit-msg hook to pre fill the commit message file
+func prepareMsg(confPath, msgPath, source string) error {
+	s, err := getScaffold(confPath)
+	if handleError(err, "Failed to create commit template") != nil {
+		return err
+	}
+
+	msgPath = filepath.Clean(msgPath)
+	content, err := os.ReadFile(msgPath)
+	if handleError(err, "Failed to read commit message file") != nil {
+		return err
+	}
+
+	// branch reference is optional, HEAD can be detached
+	branch, _ := git.CurrentBranch()
+
+	msg := string(content)
+	s.CommentChar, err = git.CommentChar(msg)
+	if handleError(err, "Failed to get comment char") != nil {
+		return err
+	}
+
+	prepared := s.Prepare(msg, source, branch)
+	if prepared == msg {
+		return nil
+	}
+	return handleError(os.WriteFile(msgPath, []byte(prepared), 0600), "Failed to write commit message file")
+}
+
+func getScaffold(confPath string) (*scaffold.Scaffold, error) {
+	conf, err := getConfig(confPath)
+	if handleError(err, "Failed to get configuration") != nil {
+		return nil, err
+	}
+
+	rules, err := config.GetEnabledRules(conf)
+	if handleError(err, "Failed to get enabled rules") != nil {
+		return nil, err
+	}
+	return scaffold.New(rules), nil
+}
@@ -0,0 +1,158 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Cleanup Modes, same as 'git commit --cleanup'
+const (
+	// CleanupDefault is strip if message is edited, otherwise whitespace
+	CleanupDefault = "default"
+
+	// CleanupStrip removes comment lines and cleans whitespace
+	CleanupStrip = "strip"
+
+	// CleanupWhitespace cleans whitespace, comment lines and scissors line are kept
+	CleanupWhitespace = "whitespace"
+
+	// CleanupScissors removes everything from the scissors line and cleans whitespace
+	CleanupScissors = "scissors"
+
+	// CleanupVerbatim keeps the message unchanged
+	CleanupVerbatim = "verbatim"
+)
+
+// defaultCommentChar is used if core.commentChar is not set
+const defaultCommentChar = "#"
+
+// autoCommentChars are the chars git chooses from for core.commentChar 'auto'
+const autoCommentChars = "#;@!$%^&|:"
+
+// ScissorsLine marks the start of diff added by 'git commit --verbose'
+const ScissorsLine = " ------------------------ >8 ------------------------"
+
+// CleanupMode returns commit.cleanup from git config, defaults to CleanupDefault
+func CleanupMode() (string, error) {
+	mode, err := Config("commit.cleanup")
+	if err != nil {
+		return "", err
+	}
+	if mode == "" {
+		return CleanupDefault, nil
+	}
+	return mode, nil
+}
+
+// IsEditorUsed reports if commit message is edited in editor
+// git sets GIT_EDITOR to ':' for hooks when editor is not used, like 'commit -m'
+func IsEditorUsed() bool {
+	return os.Getenv("GIT_EDITOR") != ":"
+}
+
+// ResolveCleanupMode returns the mode git uses for mode, CleanupDefault is
+// CleanupStrip for edited message and CleanupWhitespace for others
+func ResolveCleanupMode(mode string, isEdited bool) string {
+	if mode != CleanupDefault {
+		return mode
+	}
+	if isEdited {
+		return CleanupStrip
+	}
+	return CleanupWhitespace
+}
+
+// CommentChar returns core.commentChar from git config for msg
+// for 'auto', the comment char of comment lines in msg is returned
+func CommentChar(msg string) (string, error) {
+	char, err := Config("core.commentChar")
+	if err != nil {
+		return "", err
+	}
+	return ResolveCommentChar(char, msg), nil
+}
+
+// ResolveCommentChar returns the comment char for msg, char is the value
+// of core.commentChar read once by callers linting many messages
+func ResolveCommentChar(char, msg string) string {
+	switch char {
+	case "":
+		return defaultCommentChar
+	case "auto":
+		return detectCommentChar(msg)
+	}
+	return char
+}
+
+// detectCommentChar returns the comment char chosen by git for 'auto'
+// git adds comment lines at the end of message
+func detectCommentChar(msg string) string {
+	lines := strings.Split(strings.TrimRight(msg, " \t\n"), "\n")
+	last := lines[len(lines)-1]
+	if last != "" && strings.ContainsRune(autoCommentChars, rune(last[0])) {
+		return last[:1]
+	}
+	return defaultCommentChar
+}
+
+// CleanupMessage returns msg cleaned up as git does before creating commit
+// CleanupDefault should be resolved with ResolveCleanupMode
+// strip also cuts at scissors line, as it is added by 'git commit --verbose'
+func CleanupMessage(msg, mode, commentChar string) (string, error) {
+	switch mode {
+	case CleanupVerbatim:
+		return msg, nil
+	case CleanupWhitespace:
+		return cleanupWhitespace(msg, ""), nil
+	case CleanupScissors:
+		return cleanupWhitespace(cutScissors(msg, commentChar), ""), nil
+	case CleanupStrip:
+		return cleanupWhitespace(cutScissors(msg, commentChar), commentChar), nil
+	}
+	return "", fmt.Errorf("invalid cleanup mode '%s', should be one of [%s %s %s %s]",
+		mode, CleanupStrip, CleanupWhitespace, CleanupScissors, CleanupVerbatim)
+}
+
+// cutScissors removes scissors line and everything after it
+func cutScissors(msg, commentChar string) string {
+	cut := commentChar + ScissorsLine + "\n"
+	if strings.HasPrefix(msg, cut) {
+		return ""
+	}
+	if ind := strings.Index(msg, "\n"+cut); ind >= 0 {
+		return msg[:ind+1]
+	}
+	return msg
+}
+
+// cleanupWhitespace removes trailing whitespace, leading and trailing empty
+// lines and consecutive empty lines, same as 'git stripspace'.
+// lines starting with commentChar are removed if it is not empty
+func cleanupWhitespace(msg, commentChar string) string {
+	var lines []string
+	isEmpty := true
+
+	for _, line := range strings.Split(msg, "\n") {
+		if commentChar != "" && strings.HasPrefix(line, commentChar) {
+			continue
+		}
+
+		line = strings.TrimRight(line, " \t\r\v\f")
+		if line == "" {
+			isEmpty = true
+			continue
+		}
+
+		if isEmpty && len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		isEmpty = false
+		lines = append(lines, line)
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
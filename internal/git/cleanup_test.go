@@ -0,0 +1,109 @@
+package git
+
+import "testing"
+
+func TestCleanupMessage(t *testing.T) {
+	verbose := "feat: add login\n\n# Please enter the commit message\n" +
+		"# ------------------------ >8 ------------------------\n" +
+		"# Do not modify or remove the line above.\ndiff --git a/x b/x\n+# added line\n"
+
+	tests := []struct {
+		name        string
+		msg         string
+		mode        string
+		commentChar string
+		want        string
+	}{
+		{"strip comments", "feat: add login\n# comment\n\nbody  \n\n\n# end\n", CleanupStrip, "#", "feat: add login\n\nbody\n"},
+		{"strip scissors", verbose, CleanupStrip, "#", "feat: add login\n"},
+		{"custom comment char", "feat: add #12\n; comment\n", CleanupStrip, ";", "feat: add #12\n"},
+		{"whitespace keeps comments", "feat: add login  \n\n\n# comment\n", CleanupWhitespace, "#", "feat: add login\n\n# comment\n"},
+		{"whitespace keeps issue line", "feat: x\n\n#123 closes\n", CleanupWhitespace, "#", "feat: x\n\n#123 closes\n"},
+		{"whitespace keeps scissors", verbose, CleanupWhitespace, "#", verbose},
+		{"scissors", verbose, CleanupScissors, "#", "feat: add login\n\n# Please enter the commit message\n"},
+		{"scissors at start", "# ------------------------ >8 ------------------------\ndiff\n", CleanupScissors, "#", ""},
+		{"verbatim", "feat: add login  \n# comment\n", CleanupVerbatim, "#", "feat: add login  \n# comment\n"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := CleanupMessage(tc.msg, tc.mode, tc.commentChar)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	_, err := CleanupMessage("feat: x", "unknown", "#")
+	if err == nil {
+		t.Error("expected error for unknown cleanup mode")
+	}
+}
+
+func TestResolveCleanupMode(t *testing.T) {
+	tests := []struct {
+		mode     string
+		isEdited bool
+		want     string
+	}{
+		{CleanupDefault, true, CleanupStrip},
+		{CleanupDefault, false, CleanupWhitespace},
+		{CleanupScissors, false, CleanupScissors},
+		{CleanupVerbatim, true, CleanupVerbatim},
+	}
+
+	for _, tc := range tests {
+		if got := ResolveCleanupMode(tc.mode, tc.isEdited); got != tc.want {
+			t.Errorf("ResolveCleanupMode(%q, %v) = %q, want %q", tc.mode, tc.isEdited, got, tc.want)
+		}
+	}
+
+	// 'git commit -m "feat: x" -m "#123 closes"' runs hook without editor
+	mode := ResolveCleanupMode(CleanupDefault, false)
+	got, err := CleanupMessage("feat: x\n\n#123 closes\n", mode, "#")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "feat: x\n\n#123 closes\n" {
+		t.Errorf("got %q, want issue line kept", got)
+	}
+}
+
+func TestResolveCommentChar(t *testing.T) {
+	msg := "feat: add\n\n; Please enter the commit message\n"
+	tests := []struct {
+		char string
+		want string
+	}{
+		{"", "#"},
+		{"%", "%"},
+		{"auto", ";"},
+	}
+
+	for _, tc := range tests {
+		if got := ResolveCommentChar(tc.char, msg); got != tc.want {
+			t.Errorf("ResolveCommentChar(%q) = %q, want %q", tc.char, got, tc.want)
+		}
+	}
+}
+
+func TestDetectCommentChar(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want string
+	}{
+		{"#123 fix\n\n; Please enter the commit message\n;\n", ";"},
+		{"feat: add\n\n# comment\n", "#"},
+		{"feat: add\n", "#"},
+		{"", "#"},
+	}
+
+	for _, tc := range tests {
+		if got := detectCommentChar(tc.msg); got != tc.want {
+			t.Errorf("detectCommentChar(%q) = %q, want %q", tc.msg, got, tc.want)
+		}
+	}
+}
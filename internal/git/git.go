@@ -0,0 +1,210 @@
+// Package git contains helpers to query git repository information
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// separators used in git log format
+const (
+	fieldSep  = "\x1f"
+	recordSep = "\x1e"
+)
+
+// Commit represent a commit read from git history
+type Commit struct {
+	Hash        string
+	AuthorName  string
+	AuthorEmail string
+	AuthorDate  time.Time
+	Message     string
+}
+
+// ShortHash returns the abbreviated commit hash
+func (c *Commit) ShortHash() string {
+	if len(c.Hash) > 7 {
+		return c.Hash[:7]
+	}
+	return c.Hash
+}
+
+// CurrentBranch returns the short name of the currently checked out branch
+// returns empty string when HEAD is detached
+func CurrentBranch() (string, error) {
+	out, err := run("symbolic-ref", "--short", "-q", "HEAD")
+	if err != nil {
+		// symbolic-ref fails for detached HEAD too, check if inside a repo
+		_, repoErr := run("rev-parse", "--git-dir")
+		if repoErr != nil {
+			return "", repoErr
+		}
+		return "", nil
+	}
+	return out, nil
+}
+
+// Identity returns user.name and user.email from git config
+// empty values are returned for unset keys
+func Identity() (name, email string, err error) {
+	name, err = Config("user.name")
+	if err != nil {
+		return "", "", err
+	}
+	email, err = Config("user.email")
+	if err != nil {
+		return "", "", err
+	}
+	return name, email, nil
+}
+
+// Config Scopes
+const (
+	// ScopeDefault reads config from all files, writes to repository config
+	ScopeDefault = ""
+	ScopeLocal   = "--local"
+	ScopeGlobal  = "--global"
+)
+
+// Config returns the value of given git config key, empty if not set
+func Config(key string) (string, error) {
+	return ScopedConfig(ScopeDefault, key)
+}
+
+// ScopedConfig returns the value of given git config key in scope, empty if not set
+func ScopedConfig(scope, key string) (string, error) {
+	out, err := run(configArgs(scope, "--get", key)...)
+	if err != nil {
+		// git config exits with 1 if key is not set
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return "", nil
+		}
+		return "", err
+	}
+	return out, nil
+}
+
+// LatestTag returns the most recent tag reachable from given revision
+// returns empty string if there are no tags
+func LatestTag(rev string) (string, error) {
+	out, err := run("describe", "--tags", "--abbrev=0", rev)
+	if err != nil {
+		// describe fails when there are no tags, check if revision is valid
+		_, revErr := run("rev-parse", "--verify", "--quiet", rev+"^{commit}")
+		if revErr != nil {
+			return "", err
+		}
+		return "", nil
+	}
+	return out, nil
+}
+
+// SetConfig sets the git config key to value in given scope
+func SetConfig(scope, key, value string) error {
+	_, err := run(configArgs(scope, key, value)...)
+	return err
+}
+
+// UnsetConfig removes the git config key in given scope
+// it is not an error if the key is not set
+func UnsetConfig(scope, key string) error {
+	_, err := run(configArgs(scope, "--unset", key)...)
+	if err != nil {
+		// git config exits with 5 if key is not set
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 5 {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func configArgs(scope string, args ...string) []string {
+	if scope == ScopeDefault {
+		return append([]string{"config"}, args...)
+	}
+	return append([]string{"config", scope}, args...)
+}
+
+// Tags returns the tags reachable from given revision
+func Tags(rev string) ([]string, error) {
+	out, err := run("tag", "--merged", rev)
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// Log returns non-merge commits selected by given git log args
+// like revision range, ordered from oldest to newest
+func Log(args ...string) ([]*Commit, error) {
+	format := "--format=" + strings.Join([]string{"%H", "%an", "%ae", "%aI", "%B"}, fieldSep) + recordSep
+	logArgs := append([]string{"log", "--no-merges", "--reverse", format}, args...)
+
+	out, err := run(logArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []*Commit
+	for _, record := range strings.Split(out, recordSep) {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
+			continue
+		}
+
+		fields := strings.SplitN(record, fieldSep, 5)
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("git log: unexpected output %q", record)
+		}
+
+		date, err := time.Parse(time.RFC3339, fields[3])
+		if err != nil {
+			return nil, fmt.Errorf("git log: invalid date: %w", err)
+		}
+
+		commits = append(commits, &Commit{
+			Hash:        fields[0],
+			AuthorName:  fields[1],
+			AuthorEmail: fields[2],
+			AuthorDate:  date,
+			Message:     strings.TrimSpace(fields[4]),
+		})
+	}
+	return commits, nil
+}
+
+// run executes git with given args and returns the trimmed stdout
+func run(args ...string) (string, error) {
+	return runIn("", args...)
+}
+
+// runIn executes git in dir, empty dir is current directory
+func runIn(dir string, args ...string) (string, error) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if err != nil {
+		errMsg := strings.TrimSpace(stderr.String())
+		if errMsg == "" {
+			return "", fmt.Errorf("git %s: %w", args[0], err)
+		}
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, errMsg)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,111 @@
+package git
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Repo represent the paths of a git repository
+// it handles linked worktrees, submodules and bare repositories
+type Repo struct {
+	// TopLevel is the root of current working tree, empty for bare repository
+	TopLevel string
+
+	// GitDir is the git dir of current working tree
+	// like '.git', '.git/worktrees/name' or '.git/modules/name'
+	GitDir string
+
+	// CommonDir is the git dir shared by all working trees
+	// config and hooks are stored in common dir
+	CommonDir string
+
+	// IsBare is true for repository without working tree
+	IsBare bool
+
+	dir string
+}
+
+// OpenRepo returns the repository containing dir, all paths are absolute
+func OpenRepo(dir string) (*Repo, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	// git returns paths with symlinks resolved
+	absDir, err = filepath.EvalSymlinks(absDir)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := runIn(absDir, "rev-parse", "--is-bare-repository", "--absolute-git-dir", "--git-common-dir")
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(out, "\n")
+	r := &Repo{
+		IsBare:    lines[0] == "true",
+		GitDir:    filepath.Clean(lines[1]),
+		CommonDir: absPath(absDir, lines[2]),
+		dir:       absDir,
+	}
+
+	if r.IsBare {
+		return r, nil
+	}
+
+	r.TopLevel, err = runIn(absDir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	r.TopLevel = filepath.Clean(r.TopLevel)
+	return r, nil
+}
+
+// IsLinkedWorktree returns true for working trees added with 'git worktree add'
+func (r *Repo) IsLinkedWorktree() bool {
+	return r.GitDir != r.CommonDir
+}
+
+// MainTopLevel returns the root of main working tree, which is the current
+// working tree unless current is a linked worktree. Empty for bare repository
+func (r *Repo) MainTopLevel() (string, error) {
+	if !r.IsLinkedWorktree() {
+		return r.TopLevel, nil
+	}
+
+	out, err := runIn(r.dir, "worktree", "list", "--porcelain")
+	if err != nil {
+		return "", err
+	}
+
+	// first entry is the main working tree
+	record, _, _ := strings.Cut(out, "\n\n")
+	var path string
+	for _, line := range strings.Split(record, "\n") {
+		if line == "bare" {
+			return "", nil
+		}
+		if p, ok := strings.CutPrefix(line, "worktree "); ok {
+			path = p
+		}
+	}
+	return filepath.Clean(path), nil
+}
+
+// Path returns the absolute path of given file in git dir, like 'hooks' or
+// 'COMMIT_EDITMSG'. It respects core.hooksPath and files shared by worktrees
+func (r *Repo) Path(name string) (string, error) {
+	out, err := runIn(r.dir, "rev-parse", "--git-path", name)
+	if err != nil {
+		return "", err
+	}
+	return absPath(r.dir, out), nil
+}
+
+func absPath(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(dir, path)
+}
@@ -0,0 +1,143 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// newTestRepo creates a repository with one commit in a temp dir
+func newTestRepo(t *testing.T) string {
+	t.Helper()
+
+	// ignore user and system config of the machine running tests
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := filepath.Join(dir, "repo")
+	gitCmd(t, dir, "init", "-q", repo)
+	gitCmd(t, repo, "-c", "user.name=test", "-c", "user.email=test@example.com",
+		"commit", "-q", "--allow-empty", "-m", "feat: initial commit")
+	return repo
+}
+
+func gitCmd(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v: %s", args, err, out)
+	}
+}
+
+func TestOpenRepo(t *testing.T) {
+	mainRepo := newTestRepo(t)
+	base := filepath.Dir(mainRepo)
+
+	subDir := filepath.Join(mainRepo, "sub")
+	err := os.Mkdir(subDir, 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	worktree := filepath.Join(base, "worktree")
+	gitCmd(t, mainRepo, "worktree", "add", "-q", worktree)
+
+	sub := newTestRepo(t)
+	gitCmd(t, mainRepo, "-c", "protocol.file.allow=always", "submodule", "add", "-q", sub, "module")
+
+	bare := filepath.Join(base, "bare.git")
+	gitCmd(t, base, "init", "-q", "--bare", bare)
+
+	mainGitDir := filepath.Join(mainRepo, ".git")
+	moduleGitDir := filepath.Join(mainGitDir, "modules", "module")
+
+	tests := []struct {
+		name         string
+		dir          string
+		topLevel     string
+		gitDir       string
+		commonDir    string
+		mainTopLevel string
+		isBare       bool
+	}{
+		{"main", mainRepo, mainRepo, mainGitDir, mainGitDir, mainRepo, false},
+		{"sub directory", subDir, mainRepo, mainGitDir, mainGitDir, mainRepo, false},
+		{"linked worktree", worktree, worktree, filepath.Join(mainGitDir, "worktrees", "worktree"), mainGitDir, mainRepo, false},
+		{"submodule", filepath.Join(mainRepo, "module"), filepath.Join(mainRepo, "module"), moduleGitDir, moduleGitDir, filepath.Join(mainRepo, "module"), false},
+		{"bare", bare, "", bare, bare, "", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, err := OpenRepo(tc.dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if repo.TopLevel != tc.topLevel {
+				t.Errorf("TopLevel = %s, want %s", repo.TopLevel, tc.topLevel)
+			}
+			if repo.GitDir != tc.gitDir {
+				t.Errorf("GitDir = %s, want %s", repo.GitDir, tc.gitDir)
+			}
+			if repo.CommonDir != tc.commonDir {
+				t.Errorf("CommonDir = %s, want %s", repo.CommonDir, tc.commonDir)
+			}
+			if repo.IsBare != tc.isBare {
+				t.Errorf("IsBare = %v, want %v", repo.IsBare, tc.isBare)
+			}
+
+			mainTopLevel, err := repo.MainTopLevel()
+			if err != nil || mainTopLevel != tc.mainTopLevel {
+				t.Errorf("MainTopLevel = (%s, %v), want %s", mainTopLevel, err, tc.mainTopLevel)
+			}
+
+			hooks, err := repo.Path("hooks")
+			if err != nil || hooks != filepath.Join(tc.commonDir, "hooks") {
+				t.Errorf("hooks path = (%s, %v), want in common dir %s", hooks, err, tc.commonDir)
+			}
+
+			editMsg, err := repo.Path("COMMIT_EDITMSG")
+			if err != nil || editMsg != filepath.Join(tc.gitDir, "COMMIT_EDITMSG") {
+				t.Errorf("COMMIT_EDITMSG path = (%s, %v), want in git dir %s", editMsg, err, tc.gitDir)
+			}
+		})
+	}
+}
+
+func TestOpenRepoHooksPath(t *testing.T) {
+	mainRepo := newTestRepo(t)
+	gitCmd(t, mainRepo, "config", "core.hooksPath", ".husky")
+
+	repo, err := OpenRepo(mainRepo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hooks, err := repo.Path("hooks")
+	if err != nil || hooks != filepath.Join(mainRepo, ".husky") {
+		t.Errorf("hooks path = (%s, %v), want core.hooksPath", hooks, err)
+	}
+}
+
+func TestOpenRepoOutsideRepo(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+
+	_, err = OpenRepo(dir)
+	if err == nil {
+		t.Error("expected error outside repository")
+	}
+}
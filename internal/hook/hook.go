@@ -2,31 +2,455 @@
 package hook
 
 import (
+	"bufio"
+	"errors"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
 )
 
-// commitMsgHook represent commit-msg hook file name
-const commitMsgHook = "commit-msg"
+// hook file names written by commitlint
+const (
+	commitMsgHook        = "commit-msg"
+	prepareCommitMsgHook = "prepare-commit-msg"
+	prePushHook          = "pre-push"
+	applyPatchMsgHook    = "applypatch-msg"
+)
+
+// Version of the hook template, increment when template changes
+// so that outdated installed hooks can be detected
+const Version = 6
+
+// hook file markers, followed by template version and options
+const (
+	versionMarker = "# commitlint-hook-version:"
+	optionsMarker = "# commitlint-hook-options:"
+)
+
+// legacyHookCommand identifies hooks created before version marker was added
+const legacyHookCommand = "commitlint lint --message"
+
+// ChainedSuffix is added to the existing hook file name when it is replaced
+// by commitlint hook in chain mode
+const ChainedSuffix = ".chained"
+
+// hookCommands are the commitlint commands run by hooks
+// commit-msg is always the first hook
+var hookCommands = []struct {
+	Name    string
+	Command string
+
+	// Required fails the hook if commitlint is not installed
+	Required bool
+
+	// Stdin is true for hooks which read input from stdin, input is
+	// passed to both the previous hook and commitlint
+	Stdin bool
+}{
+	{commitMsgHook, `commitlint lint --message "$1"`, true, false},
+	{prepareCommitMsgHook, `commitlint prepare --message "$1" --source "$2"`, false, false},
+	{prePushHook, `commitlint pre-push "$1" "$2"`, true, true},
+	{applyPatchMsgHook, `commitlint lint --message "$1"`, true, false},
+}
+
+// forwardHookNames are client side git hooks which are forwarded to the
+// previous hooksPath, as setting core.hooksPath disables them
+var forwardHookNames = []string{
+	"pre-applypatch", "post-applypatch",
+	"pre-commit", "pre-merge-commit", "post-commit",
+	"pre-rebase", "post-checkout", "post-merge", "post-rewrite",
+	"pre-auto-gc", "reference-transaction", "push-to-checkout",
+	"sendemail-validate", "post-index-change",
+}
 
-const hookMessage = `#!/bin/sh
+// Order of running the previous hook relative to commitlint
+type Order string
+
+// Order Constants
+const (
+	OrderBefore Order = "before"
+	OrderAfter  Order = "after"
+)
 
+// Options for writing hooks
+type Options struct {
+	// Chain writes only commitlint hooks to an existing hooks dir, existing
+	// hooks of same name are renamed with ChainedSuffix and run by commitlint hooks
+	//
+	// otherwise all hooks are written and they run the hook of same name
+	// in previous hooksPath
+	Chain bool
+
+	// Order of running the previous hook, defaults to OrderBefore
+	Order Order
+}
+
+// IsValid checks if options has known values
+func (o Options) IsValid() bool {
+	return o.Order == "" || o.Order == OrderBefore || o.Order == OrderAfter
+}
+
+func (o Options) String() string {
+	mode := "forward"
+	if o.Chain {
+		mode = "chain"
+	}
+	order := o.Order
+	if order == "" {
+		order = OrderBefore
+	}
+	return "mode=" + mode + " order=" + string(order)
+}
+
+// runPrevious is shell function which runs the hook replaced by commitlint
+const runPrevious = `# run_previous runs the hook replaced by commitlint with same arguments
+run_previous() {
+	hook_dir=$(cd "$(dirname "$0")" && pwd -P)
+	hook_name=$(basename "$0")
+{{- if .Chain}}
+	previous="$hook_dir/$hook_name{{.Suffix}}"
+{{- else}}
+	previous_dir=$(git config --path commitlint.previousHooksPath)
+	if [ -z "$previous_dir" ]; then
+		previous_dir="$(git rev-parse --git-common-dir)/hooks"
+	fi
+	# previous hooksPath same as this dir would run the hook again
+	if [ ! -d "$previous_dir" ] || [ "$(cd "$previous_dir" && pwd -P)" = "$hook_dir" ]; then
+		return 0
+	fi
+	previous="$previous_dir/$hook_name"
+{{- end}}
+	if [ -x "$previous" ]; then
+		"$previous" "$@"
+	fi
+}
+`
+
+var commitlintTemplate = template.Must(template.New("commitlint").Parse(`#!/bin/sh
+` + versionMarker + ` {{.Version}}
+` + optionsMarker + ` {{.Options}}
+
+` + runPrevious + `
+# previous hook runs even if commitlint is not installed
 if ! type commitlint >/dev/null 2>/dev/null; then
+{{- if .Required}}
 	echo ""
     echo "commitlint could not be found"
     echo "try again after installing commitlint or add commitlint to PATH"
 	echo ""
+	run_previous "$@"
     exit 2;
+{{- else}}
+	# commitlint is optional for this hook
+	run_previous "$@"
+	exit $?
+{{- end}}
 fi
 
-commitlint lint --message $1
+{{define "stdin"}}{{if .Stdin}}printf '%s\n' "$input" | {{end}}{{end -}}
+{{if .Stdin -}}
+# stdin can be read only once, it is passed to both commands
+input=$(cat)
 
-`
+{{end -}}
+{{if eq .Order "after" -}}
+{{template "stdin" .}}{{.Command}} || exit $?
+{{template "stdin" .}}run_previous "$@"
+{{- else -}}
+{{template "stdin" .}}run_previous "$@" || exit $?
+{{template "stdin" .}}{{.Command}}
+{{- end}}
+
+`))
+
+var forwardTemplate = template.Must(template.New("forward").Parse(`#!/bin/sh
+` + versionMarker + ` {{.Version}}
+` + optionsMarker + ` {{.Options}}
+
+` + runPrevious + `
+run_previous "$@"
+`))
+
+// Status represent the state of a hook file in hooks directory
+type Status struct {
+	Name string
+	Path string
+
+	Exists     bool
+	Executable bool
+
+	// IsCommitlint is true if hook file is created by commitlint
+	IsCommitlint bool
+
+	// Forward is true for hooks which only run the hook in previous hooksPath
+	Forward bool
+
+	// Version of hook template, 0 for hooks created before versioning
+	Version int
+
+	// Options used to create the hook
+	Options Options
+}
+
+// IsCurrent returns true if hook is created by current version of template
+func (s Status) IsCurrent() bool {
+	return s.IsCommitlint && s.Version == Version
+}
 
 // WriteHooks write git hooks to the given outDir
-func WriteHooks(outDir string) (retErr error) {
-	hookFilePath := filepath.Join(outDir, commitMsgHook)
-	// commit-msg needs to be executable
+// in chain mode, existing hooks of same name as commitlint hooks are renamed
+// and run by commitlint hooks. otherwise forwarding hooks are written too,
+// existing forwarding hooks are not overwritten
+func WriteHooks(outDir string, opts Options) error {
+	if opts.Chain {
+		err := chainExisting(outDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, h := range hookCommands {
+		err := writeHook(filepath.Join(outDir, h.Name), h.Name, opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.Chain {
+		return nil
+	}
+
+	statuses, err := HookStatus(outDir)
+	if err != nil {
+		return err
+	}
+	for _, s := range statuses {
+		if !s.Forward || (s.Exists && !s.IsCommitlint) {
+			continue
+		}
+		err = writeHook(s.Path, s.Name, opts)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// chainExisting renames the existing hooks in dir which are not created by
+// commitlint, all chained paths are checked before renaming any hook
+func chainExisting(dir string) error {
+	var hookPaths []string
+	for _, h := range hookCommands {
+		hookPath := filepath.Join(dir, h.Name)
+		isOther, err := isOtherHook(hookPath)
+		if err != nil {
+			return err
+		}
+		if !isOther {
+			continue
+		}
+
+		chainedPath := hookPath + ChainedSuffix
+		if _, err := os.Stat(chainedPath); err == nil {
+			return errors.New("chained hook already exists: " + chainedPath)
+		}
+		hookPaths = append(hookPaths, hookPath)
+	}
+
+	for _, hookPath := range hookPaths {
+		err := os.Rename(hookPath, hookPath+ChainedSuffix)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isOtherHook checks if hook file exists and is not created by commitlint
+func isOtherHook(hookPath string) (bool, error) {
+	content, err := os.ReadFile(hookPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	_, isCommitlint := ParseVersion(string(content))
+	return !isCommitlint, nil
+}
+
+// UpdateHooks rewrites commitlint hooks in dir which are missing or outdated
+// with the options of existing commit-msg hook
+// hook files not created by commitlint are left unchanged
+// returns the names of updated hooks
+func UpdateHooks(dir string) ([]string, error) {
+	statuses, err := HookStatus(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated []string
+	for _, s := range statuses {
+		if s.Exists && (!s.IsCommitlint || (s.IsCurrent() && s.Executable)) {
+			continue
+		}
+
+		err = writeHook(s.Path, s.Name, statuses[0].Options)
+		if err != nil {
+			return updated, err
+		}
+		// file mode is not changed for existing files
+		err = os.Chmod(s.Path, 0700)
+		if err != nil {
+			return updated, err
+		}
+		updated = append(updated, s.Name)
+	}
+	return updated, nil
+}
+
+// RemoveHooks removes commitlint hooks from dir and restores chained hooks
+// dir is removed if it is empty and hooks are not chained
+// hook files not created by commitlint are left unchanged
+// returns the names of removed hooks
+func RemoveHooks(dir string) ([]string, error) {
+	statuses, err := HookStatus(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	for _, s := range statuses {
+		if !s.IsCommitlint {
+			continue
+		}
+		err = os.Remove(s.Path)
+		if err != nil {
+			return removed, err
+		}
+		removed = append(removed, s.Name)
+
+		err = os.Rename(s.Path+ChainedSuffix, s.Path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return removed, err
+		}
+	}
+
+	if statuses[0].Options.Chain {
+		return removed, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err == nil && len(entries) == 0 {
+		err = os.Remove(dir)
+		if err != nil {
+			return removed, err
+		}
+	}
+	return removed, nil
+}
+
+// HookStatus returns the status of commitlint hooks in dir, first status
+// is always commit-msg hook. Forwarding hooks are included unless commit-msg
+// is written in chain mode
+func HookStatus(dir string) ([]Status, error) {
+	statuses := make([]Status, 0, len(hookCommands)+len(forwardHookNames))
+	for _, h := range hookCommands {
+		s, err := readStatus(dir, h.Name)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, s)
+	}
+
+	if statuses[0].Options.Chain {
+		return statuses, nil
+	}
+
+	for _, name := range forwardHookNames {
+		s, err := readStatus(dir, name)
+		if err != nil {
+			return nil, err
+		}
+		s.Forward = true
+		statuses = append(statuses, s)
+	}
+	return statuses, nil
+}
+
+func readStatus(dir, name string) (Status, error) {
+	s := Status{Name: name, Path: filepath.Join(dir, name)}
+
+	info, err := os.Stat(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+
+	s.Exists = true
+	s.Executable = info.Mode().Perm()&0111 != 0
+
+	content, err := os.ReadFile(s.Path)
+	if err != nil {
+		return s, err
+	}
+	s.Version, s.IsCommitlint = ParseVersion(string(content))
+	s.Options = parseOptions(string(content))
+	return s, nil
+}
+
+// ParseVersion returns the template version of hook content
+// and whether the hook is created by commitlint
+func ParseVersion(content string) (int, bool) {
+	ver, ok := markerValue(content, versionMarker)
+	if !ok {
+		// hooks created before versioning has no marker
+		return 0, strings.Contains(content, legacyHookCommand)
+	}
+
+	num, err := strconv.Atoi(ver)
+	if err != nil {
+		return 0, true
+	}
+	return num, true
+}
+
+// parseOptions returns the options written in hook content
+// hooks created before options were added has default options
+func parseOptions(content string) Options {
+	var opts Options
+
+	value, _ := markerValue(content, optionsMarker)
+	for _, field := range strings.Fields(value) {
+		key, val, _ := strings.Cut(field, "=")
+		switch key {
+		case "mode":
+			opts.Chain = val == "chain"
+		case "order":
+			opts.Order = Order(val)
+		}
+	}
+	return opts
+}
+
+func markerValue(content, marker string) (string, bool) {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		value, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), marker)
+		if ok {
+			return strings.TrimSpace(value), true
+		}
+	}
+	return "", false
+}
+
+func writeHook(hookFilePath, name string, opts Options) (retErr error) {
+	// hooks needs to be executable
 	file, err := os.OpenFile(hookFilePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0700)
 	if err != nil {
 		return err
@@ -39,9 +463,23 @@ func WriteHooks(outDir string) (retErr error) {
 		}
 	}()
 
-	_, err = file.WriteString(hookMessage)
-	if err != nil {
-		return err
+	data := struct {
+		Version  int
+		Options  Options
+		Chain    bool
+		Order    Order
+		Suffix   string
+		Command  string
+		Required bool
+		Stdin    bool
+	}{Version, opts, opts.Chain, opts.Order, ChainedSuffix, "", false, false}
+
+	tmpl := forwardTemplate
+	for _, h := range hookCommands {
+		if h.Name == name {
+			tmpl = commitlintTemplate
+			data.Command, data.Required, data.Stdin = h.Command, h.Required, h.Stdin
+		}
 	}
-	return nil
+	return tmpl.Execute(file, data)
 }
@@ -0,0 +1,215 @@
+package hook
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		version      int
+		isCommitlint bool
+	}{
+		{"current", "#!/bin/sh\n" + versionMarker + " 2\n", 2, true},
+		{"legacy", "#!/bin/sh\n\ncommitlint lint --message $1\n", 0, true},
+		{"invalid version", "#!/bin/sh\n" + versionMarker + " x\n", 0, true},
+		{"other hook", "#!/bin/sh\n\nnpx commitlint --edit $1\n", 0, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			version, isCommitlint := ParseVersion(tc.content)
+			if version != tc.version || isCommitlint != tc.isCommitlint {
+				t.Errorf("got (%d, %v), want (%d, %v)", version, isCommitlint, tc.version, tc.isCommitlint)
+			}
+		})
+	}
+}
+
+func TestUpdateAndRemoveHooks(t *testing.T) {
+	dir := t.TempDir()
+	hookPath := filepath.Join(dir, commitMsgHook)
+
+	err := os.WriteFile(hookPath, []byte("#!/bin/sh\ncommitlint lint --message $1\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := UpdateHooks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// legacy hooks are updated with forwarding hooks
+	if len(updated) != len(hookCommands)+len(forwardHookNames) {
+		t.Fatalf("got updated %v, want commitlint and forwarding hooks", updated)
+	}
+
+	statuses, err := HookStatus(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := statuses[0]; !s.IsCurrent() || !s.Executable {
+		t.Errorf("hook not current after update: %+v", s)
+	}
+
+	current, err := UpdateHooks(dir)
+	if err != nil || len(current) != 0 {
+		t.Errorf("got (%v, %v) for current hooks, want no updates", current, err)
+	}
+
+	removed, err := RemoveHooks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(removed) != len(updated) {
+		t.Errorf("got removed %v, want %v", removed, updated)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("empty hooks dir not removed")
+	}
+}
+
+func TestRemoveHooksKeepsOtherHooks(t *testing.T) {
+	dir := t.TempDir()
+	hookPath := filepath.Join(dir, commitMsgHook)
+
+	err := os.WriteFile(hookPath, []byte("#!/bin/sh\nexit 0\n"), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := RemoveHooks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(removed) != 0 {
+		t.Errorf("got removed %v, want none", removed)
+	}
+	if _, err := os.Stat(hookPath); err != nil {
+		t.Errorf("other hook removed: %v", err)
+	}
+}
+
+func TestChainHooks(t *testing.T) {
+	dir := t.TempDir()
+	hookPath := filepath.Join(dir, commitMsgHook)
+	existing := []byte("#!/bin/sh\nexit 0\n")
+
+	err := os.WriteFile(hookPath, existing, 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = WriteHooks(dir, Options{Chain: true, Order: OrderAfter})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statuses, err := HookStatus(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != len(hookCommands) || !statuses[0].IsCurrent() {
+		t.Fatalf("got statuses %+v, want only current commitlint hooks", statuses)
+	}
+	if opts := statuses[0].Options; !opts.Chain || opts.Order != OrderAfter {
+		t.Errorf("got options %+v, want chain mode with order after", opts)
+	}
+
+	chained, err := os.ReadFile(hookPath + ChainedSuffix)
+	if err != nil || string(chained) != string(existing) {
+		t.Fatalf("existing hook not chained: %v", err)
+	}
+
+	_, err = RemoveHooks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored, err := os.ReadFile(hookPath)
+	if err != nil || string(restored) != string(existing) {
+		t.Errorf("existing hook not restored: %v", err)
+	}
+}
+
+func TestChainHooksExistingChained(t *testing.T) {
+	dir := t.TempDir()
+	existing := []byte("#!/bin/sh\nexit 0\n")
+
+	for _, name := range []string{commitMsgHook, prePushHook, prePushHook + ChainedSuffix} {
+		err := os.WriteFile(filepath.Join(dir, name), existing, 0700)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := WriteHooks(dir, Options{Chain: true})
+	if err == nil {
+		t.Fatal("expected error for existing chained hook")
+	}
+
+	// no hook is renamed if any chained hook exists
+	if _, err := os.Stat(filepath.Join(dir, commitMsgHook+ChainedSuffix)); !os.IsNotExist(err) {
+		t.Errorf("commit-msg hook is chained: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, commitMsgHook))
+	if err != nil || string(content) != string(existing) {
+		t.Errorf("commit-msg hook is changed: %v", err)
+	}
+}
+
+func TestHookWithoutCommitlint(t *testing.T) {
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "ran")
+
+	// previous hooks record their name, commitlint is not in PATH
+	previous := []byte("#!/bin/sh\necho \"$(basename \"$0\")\" >> " + marker + "\n")
+	for _, name := range []string{commitMsgHook, prepareCommitMsgHook} {
+		err := os.WriteFile(filepath.Join(dir, name), previous, 0700)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := WriteHooks(dir, Options{Chain: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		exitCode int
+	}{
+		{prepareCommitMsgHook, 0},
+		{commitMsgHook, 2},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_ = os.Remove(marker)
+
+			cmd := exec.Command(filepath.Join(dir, tc.name), "COMMIT_EDITMSG")
+			cmd.Env = []string{"PATH=/usr/bin:/bin"}
+			err := cmd.Run()
+
+			exitCode := 0
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				exitCode = exitErr.ExitCode()
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if exitCode != tc.exitCode {
+				t.Errorf("exit code = %d, want %d", exitCode, tc.exitCode)
+			}
+
+			ran, err := os.ReadFile(marker)
+			if err != nil || string(ran) != tc.name+ChainedSuffix+"\n" {
+				t.Errorf("previous hook did not run, got %q: %v", ran, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,337 @@
+// Package jsconfig converts configs of JavaScript commitlint to lint.Config
+package jsconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/zexot-com/commitlint/config"
+	"github.com/zexot-com/commitlint/lint"
+)
+
+// ConventionalConfig is the shared config supported in extends
+const ConventionalConfig = "@commitlint/config-conventional"
+
+// rule levels and applicability of JavaScript commitlint
+const (
+	levelDisabled = 0
+	levelWarn     = 1
+	levelError    = 2
+
+	always = "always"
+	never  = "never"
+)
+
+var errJSConfig = errors.New("JavaScript config files are not supported, export the config to .commitlintrc.json")
+
+// conventionalRules are the rules of @commitlint/config-conventional
+var conventionalRules = map[string][]interface{}{
+	"body-leading-blank":     {levelWarn, always},
+	"body-max-line-length":   {levelError, always, 100},
+	"footer-leading-blank":   {levelWarn, always},
+	"footer-max-line-length": {levelError, always, 100},
+	"header-max-length":      {levelError, always, 100},
+	"header-trim":            {levelError, always},
+	"subject-case":           {levelError, never, []interface{}{"sentence-case", "start-case", "pascal-case", "upper-case"}},
+	"subject-empty":          {levelError, never},
+	"subject-full-stop":      {levelError, never, "."},
+	"type-case":              {levelError, always, "lower-case"},
+	"type-empty":             {levelError, never},
+	"type-enum": {levelError, always, []interface{}{
+		"build", "chore", "ci", "docs", "feat", "fix", "perf", "refactor", "revert", "style", "test",
+	}},
+}
+
+// lengthRules maps rules with length value to rules with length argument
+var lengthRules = map[string]string{
+	"header-max-length":      "header-max-length",
+	"header-min-length":      "header-min-length",
+	"body-max-length":        "body-max-length",
+	"body-min-length":        "body-min-length",
+	"body-max-line-length":   "body-max-line-length",
+	"footer-max-length":      "footer-max-length",
+	"footer-min-length":      "footer-min-length",
+	"footer-max-line-length": "footer-max-line-length",
+	"type-max-length":        "type-max-length",
+	"type-min-length":        "type-min-length",
+	"scope-max-length":       "scope-max-length",
+	"scope-min-length":       "scope-min-length",
+	"subject-max-length":     "description-max-length",
+	"subject-min-length":     "description-min-length",
+}
+
+// emptyRules maps 'never' empty rules to min length rules with length 1
+// rules are converted in name order, so explicit min length rule overrides it
+var emptyRules = map[string]string{
+	"type-empty":    "type-min-length",
+	"scope-empty":   "scope-min-length",
+	"subject-empty": "description-min-length",
+	"body-empty":    "body-min-length",
+	"footer-empty":  "footer-min-length",
+}
+
+// enumRules maps rules with list of allowed values
+var enumRules = map[string]string{
+	"type-enum":  "type-enum",
+	"scope-enum": "scope-enum",
+}
+
+// Config represent the config of JavaScript commitlint
+type Config struct {
+	// Extends are the shared configs, string or list of strings
+	Extends interface{}
+
+	// Rules is rule name to [level, applicable, value] tuple
+	Rules map[string]interface{}
+
+	// Others are the other top level fields, like formatter or parserPreset
+	Others map[string]interface{}
+}
+
+// Skipped represent a rule or field which could not be converted
+type Skipped struct {
+	Name   string
+	Reason string
+}
+
+// Read parses config in given file content, name is used to find format
+// package.json config is read from commitlint field
+func Read(name string, data []byte) (*Config, error) {
+	switch filepath.Ext(name) {
+	case ".js", ".cjs", ".mjs", ".ts", ".cts", ".mts":
+		return nil, errJSConfig
+	}
+
+	if filepath.Base(name) == "package.json" {
+		var pkg map[string]json.RawMessage
+		err := json.Unmarshal(data, &pkg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid package.json: %w", err)
+		}
+		confData, ok := pkg["commitlint"]
+		if !ok {
+			return nil, errors.New("package.json has no commitlint field")
+		}
+		data = confData
+	}
+
+	// json config is valid yaml
+	var fields map[string]interface{}
+	err := yaml.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	conf := &Config{
+		Extends: fields["extends"],
+		Rules:   make(map[string]interface{}),
+		Others:  make(map[string]interface{}),
+	}
+
+	if rules, ok := fields["rules"]; ok && rules != nil {
+		ruleMap, ok := rules.(map[interface{}]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid config: rules should be an object, but got %#v", rules)
+		}
+		for k, v := range ruleMap {
+			conf.Rules[fmt.Sprint(k)] = v
+		}
+	}
+
+	for k, v := range fields {
+		if k != "extends" && k != "rules" {
+			conf.Others[k] = v
+		}
+	}
+	return conf, nil
+}
+
+// Convert converts conf to lint.Config, based on the default config
+// rules and fields which could not be converted are returned as skipped
+func Convert(conf *Config) (*lint.Config, []Skipped) {
+	var skipped []Skipped
+
+	rules := make(map[string]interface{})
+	for _, ext := range toStrings(conf.Extends) {
+		if ext != ConventionalConfig {
+			skipped = append(skipped, Skipped{Name: "extends: " + ext, Reason: "only " + ConventionalConfig + " is supported"})
+			continue
+		}
+		for name, r := range conventionalRules {
+			rules[name] = r
+		}
+	}
+
+	// rules in config override the rules of extended config
+	for name, r := range conf.Rules {
+		rules[name] = r
+	}
+
+	out := config.NewDefault()
+	out.Rules = nil
+	out.Severity = lint.SeverityConfig{Default: lint.SeverityError}
+
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	enabled := make(map[string]lint.Severity)
+	for _, name := range names {
+		target, setting, sev, err := convertRule(name, rules[name])
+		if err != nil {
+			skipped = append(skipped, Skipped{Name: name, Reason: err.Error()})
+			continue
+		}
+		if target == "" {
+			continue
+		}
+		enabled[target] = sev
+
+		// converted argument and flags are set on the default settings
+		ruleSetting := out.Settings[target]
+		if setting.Argument != nil {
+			ruleSetting.Argument = setting.Argument
+		}
+		for flag, val := range setting.Flags {
+			if ruleSetting.Flags == nil {
+				ruleSetting.Flags = make(map[string]interface{})
+			}
+			ruleSetting.Flags[flag] = val
+		}
+		out.Settings[target] = ruleSetting
+	}
+
+	for name := range enabled {
+		out.Rules = append(out.Rules, name)
+	}
+	sort.Strings(out.Rules)
+
+	for _, name := range out.Rules {
+		if enabled[name] == lint.SeverityWarn {
+			if out.Severity.Rules == nil {
+				out.Severity.Rules = make(map[string]lint.Severity)
+			}
+			out.Severity.Rules[name] = lint.SeverityWarn
+		}
+	}
+
+	others := make([]string, 0, len(conf.Others))
+	for name := range conf.Others {
+		others = append(others, name)
+	}
+	sort.Strings(others)
+	for _, name := range others {
+		skipped = append(skipped, Skipped{Name: name, Reason: "field is not supported"})
+	}
+	return out, skipped
+}
+
+// convertRule returns the rule name, settings and severity for given
+// rule tuple, empty name if rule is disabled
+func convertRule(name string, tuple interface{}) (string, lint.RuleSetting, lint.Severity, error) {
+	level, applicable, value, err := parseTuple(tuple)
+	if err != nil {
+		return "", lint.RuleSetting{}, "", err
+	}
+	if level == levelDisabled {
+		return "", lint.RuleSetting{}, "", nil
+	}
+
+	sev := lint.SeverityError
+	if level == levelWarn {
+		sev = lint.SeverityWarn
+	}
+
+	if target, ok := lengthRules[name]; ok {
+		length, ok := value.(int)
+		if applicable != always || !ok {
+			return "", lint.RuleSetting{}, "", fmt.Errorf("expects 'always' with length, but got '%s' %#v", applicable, value)
+		}
+		return target, lint.RuleSetting{Argument: length}, sev, nil
+	}
+
+	if target, ok := emptyRules[name]; ok {
+		if applicable != never {
+			return "", lint.RuleSetting{}, "", errors.New("only 'never' is supported")
+		}
+		return target, lint.RuleSetting{Argument: 1}, sev, nil
+	}
+
+	if target, ok := enumRules[name]; ok {
+		values, ok := value.([]interface{})
+		if applicable != always || !ok {
+			return "", lint.RuleSetting{}, "", fmt.Errorf("expects 'always' with list of values, but got '%s' %#v", applicable, value)
+		}
+		setting := lint.RuleSetting{Argument: values}
+		if target == "scope-enum" {
+			// scope-enum of JavaScript commitlint allows empty scope
+			setting.Flags = map[string]interface{}{"allow-empty": true}
+		}
+		return target, setting, sev, nil
+	}
+
+	switch name {
+	case "signed-off-by", "trailer-exists":
+		trailer, _ := value.(string)
+		if applicable != always || !strings.EqualFold(strings.TrimSuffix(trailer, ":"), "Signed-off-by") {
+			break
+		}
+		return "signed-off-by", lint.RuleSetting{}, sev, nil
+	case "references-empty":
+		if applicable != never {
+			break
+		}
+		return "references-required", lint.RuleSetting{}, sev, nil
+	}
+	return "", lint.RuleSetting{}, "", errors.New("no equivalent rule")
+}
+
+// parseTuple parses [level, applicable, value] rule tuple
+func parseTuple(tuple interface{}) (int, string, interface{}, error) {
+	arr, ok := tuple.([]interface{})
+	if !ok || len(arr) == 0 || len(arr) > 3 {
+		return 0, "", nil, fmt.Errorf("expects [level, applicable, value], but got %#v", tuple)
+	}
+
+	level, ok := arr[0].(int)
+	if !ok || level < levelDisabled || level > levelError {
+		return 0, "", nil, fmt.Errorf("unknown level %#v", arr[0])
+	}
+
+	applicable := always
+	if len(arr) > 1 {
+		applicable, ok = arr[1].(string)
+		if !ok || (applicable != always && applicable != never) {
+			return 0, "", nil, fmt.Errorf("unknown applicable %#v", arr[1])
+		}
+	}
+
+	var value interface{}
+	if len(arr) > 2 {
+		value = arr[2]
+	}
+	return level, applicable, value, nil
+}
+
+func toStrings(v interface{}) []string {
+	switch s := v.(type) {
+	case string:
+		return []string{s}
+	case []interface{}:
+		strs := make([]string, 0, len(s))
+		for _, e := range s {
+			strs = append(strs, fmt.Sprint(e))
+		}
+		return strs
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,108 @@
+package jsconfig
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/zexot-com/commitlint/config"
+	"github.com/zexot-com/commitlint/lint"
+)
+
+func TestRead(t *testing.T) {
+	pkg := `{"name": "app", "commitlint": {"extends": "@commitlint/config-conventional", "rules": {"type-enum": [2, "always", ["feat"]]}}}`
+	conf, err := Read("package.json", []byte(pkg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conf.Extends != ConventionalConfig || len(conf.Rules) != 1 {
+		t.Errorf("unexpected config %+v", conf)
+	}
+
+	yamlConf := "extends:\n  - '@commitlint/config-conventional'\nrules:\n  scope-enum: [1, always, [api]]\nformatter: '@commitlint/format'\n"
+	conf, err = Read(".commitlintrc.yaml", []byte(yamlConf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conf.Rules) != 1 || len(conf.Others) != 1 {
+		t.Errorf("unexpected config %+v", conf)
+	}
+
+	_, err = Read("package.json", []byte(`{"name": "app"}`))
+	if err == nil {
+		t.Error("expected error for package.json without commitlint field")
+	}
+
+	_, err = Read("commitlint.config.js", []byte(`module.exports = {}`))
+	if err != errJSConfig {
+		t.Errorf("got error %v, want %v", err, errJSConfig)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	conf := &Config{
+		Extends: []interface{}{ConventionalConfig, "@commitlint/config-angular"},
+		Rules: map[string]interface{}{
+			"header-max-length":  []interface{}{2, "always", 72},
+			"scope-enum":         []interface{}{1, "always", []interface{}{"api", "ui"}},
+			"subject-min-length": []interface{}{2, "always", 5},
+			"subject-case":       []interface{}{0},
+			"body-max-length":    []interface{}{2, "never", 100},
+			"references-empty":   []interface{}{2, "never"},
+		},
+		Others: map[string]interface{}{"helpUrl": "https://example.com"},
+	}
+
+	out, skipped := Convert(conf)
+
+	wantRules := []string{
+		"body-max-line-length", "description-min-length", "footer-max-line-length",
+		"header-max-length", "references-required", "scope-enum", "type-enum", "type-min-length",
+	}
+	if !reflect.DeepEqual(out.Rules, wantRules) {
+		t.Errorf("rules = %v, want %v", out.Rules, wantRules)
+	}
+
+	if out.Severity.Rules["scope-enum"] != lint.SeverityWarn || len(out.Severity.Rules) != 1 {
+		t.Errorf("unexpected severity %+v", out.Severity)
+	}
+
+	if out.Settings["header-max-length"].Argument != 72 {
+		t.Errorf("header-max-length argument = %v, want 72", out.Settings["header-max-length"].Argument)
+	}
+	// explicit subject-min-length overrides subject-empty of extended config
+	if out.Settings["description-min-length"].Argument != 5 {
+		t.Errorf("description-min-length argument = %v, want 5", out.Settings["description-min-length"].Argument)
+	}
+	if out.Settings["scope-enum"].Flags["allow-empty"] != true {
+		t.Errorf("scope-enum should allow empty scope, got flags %v", out.Settings["scope-enum"].Flags)
+	}
+
+	var names []string
+	for _, s := range skipped {
+		names = append(names, s.Name)
+	}
+	wantSkipped := []string{
+		"extends: @commitlint/config-angular",
+		"body-leading-blank", "body-max-length", "footer-leading-blank", "header-trim",
+		"subject-full-stop", "type-case", "helpUrl",
+	}
+	if !reflect.DeepEqual(names, wantSkipped) {
+		t.Errorf("skipped = %v, want %v", names, wantSkipped)
+	}
+
+	// converted config is validated as read from the written file
+	var buf bytes.Buffer
+	err := config.WriteTo(&buf, out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	written, err := config.Decode(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs := config.Validate(written)
+	if len(errs) != 0 {
+		t.Errorf("converted config is invalid: %v", errs)
+	}
+}
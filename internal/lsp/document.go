@@ -0,0 +1,312 @@
+package lsp
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/zexot-com/commitlint/internal/git"
+	"github.com/zexot-com/commitlint/lint"
+)
+
+// parserRuleName is the rule name of header parse errors
+const parserRuleName = "parser"
+
+var (
+	// tokenPrefix matches line typed up to type or footer token, like 'fe'
+	tokenPrefix = regexp.MustCompile(`^[\w-]*$`)
+
+	// scopePrefix matches header typed up to scope, like 'feat(ap'
+	scopePrefix = regexp.MustCompile(`^[\w-]+\([^)]*$`)
+)
+
+// document represent an open commit message document
+type document struct {
+	uri   string
+	text  string
+	lines []string
+
+	// commentChar is used to skip comment lines and find scissors line
+	commentChar string
+
+	// msg is the message linted, comments and diff below scissors are removed
+	msg string
+
+	// first and last are the lines of message content, -1 if empty
+	first int
+	last  int
+
+	issues []docIssue
+}
+
+// docIssue is a lint issue with its range in document
+type docIssue struct {
+	issue *lint.Issue
+	rng   Range
+}
+
+// newDocument returns document for text, commentConfig is the value
+// of core.commentChar
+func newDocument(uri, text, commentConfig string) *document {
+	d := &document{
+		uri:         uri,
+		text:        text,
+		lines:       strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n"),
+		commentChar: git.ResolveCommentChar(commentConfig, text),
+		first:       -1,
+		last:        -1,
+	}
+
+	for i, line := range d.lines {
+		if line == d.commentChar+git.ScissorsLine {
+			break
+		}
+		if d.isComment(line) || strings.TrimSpace(line) == "" {
+			continue
+		}
+		if d.first < 0 {
+			d.first = i
+		}
+		d.last = i
+	}
+
+	msg, err := git.CleanupMessage(strings.Join(d.lines, "\n"), git.CleanupStrip, d.commentChar)
+	if err == nil {
+		d.msg = strings.TrimSpace(msg)
+	}
+	return d
+}
+
+func (d *document) isComment(line string) bool {
+	return strings.HasPrefix(line, d.commentChar)
+}
+
+// lint lints the document message and sets the issues with their ranges
+func (d *document) lint(linter *lint.Linter) error {
+	d.issues = nil
+	if d.msg == "" {
+		return nil
+	}
+
+	result, err := linter.ParseAndLint(d.msg)
+	if err != nil {
+		return err
+	}
+
+	commit, parseErr := linter.Parse(d.msg)
+	for _, issue := range result.Issues() {
+		d.issues = append(d.issues, docIssue{issue: issue, rng: d.issueRange(issue, commit, parseErr)})
+	}
+	return nil
+}
+
+func (d *document) diagnostics() []Diagnostic {
+	diags := make([]Diagnostic, 0, len(d.issues))
+	for _, i := range d.issues {
+		diags = append(diags, newDiagnostic(i))
+	}
+	return diags
+}
+
+func newDiagnostic(i docIssue) Diagnostic {
+	severity := severityError
+	if i.issue.Severity() == lint.SeverityWarn {
+		severity = severityWarning
+	}
+
+	message := i.issue.Description()
+	if suggestions := i.issue.Suggestions(); len(suggestions) > 0 {
+		message += ", did you mean '" + suggestions[0] + "'?"
+	}
+
+	return Diagnostic{
+		Range:    i.rng,
+		Severity: severity,
+		Code:     i.issue.RuleName(),
+		Source:   "commitlint",
+		Message:  message,
+	}
+}
+
+// issueRange returns the range of part of message checked by the rule
+// like type for type-enum or footer for trailer rules, defaults to header
+func (d *document) issueRange(issue *lint.Issue, commit lint.Commit, parseErr error) Range {
+	if d.first < 0 {
+		return Range{}
+	}
+
+	header := d.first
+	headerLine := d.lines[header]
+	name := issue.RuleName()
+
+	if parseErr != nil || commit == nil {
+		var pe *lint.ParseError
+		if name == parserRuleName && errors.As(parseErr, &pe) && pe.Line == 1 && pe.Column > 0 {
+			off := runeOffset(headerLine, pe.Column-1)
+			return d.span(header, off, len(headerLine)-off)
+		}
+		if rng, ok := d.partRange(name, nil); ok {
+			return rng
+		}
+		return d.lineRange(header)
+	}
+
+	switch {
+	case strings.HasPrefix(name, "type-"):
+		if off := strings.Index(headerLine, commit.Type()); commit.Type() != "" && off >= 0 {
+			return d.span(header, off, len(commit.Type()))
+		}
+	case strings.HasPrefix(name, "scope-"):
+		if off := strings.Index(headerLine, "("+commit.Scope()+")"); commit.Scope() != "" && off >= 0 {
+			return d.span(header, off+1, len(commit.Scope()))
+		}
+	case strings.HasPrefix(name, "description-"):
+		if off := strings.LastIndex(headerLine, commit.Description()); commit.Description() != "" && off >= 0 {
+			return d.span(header, off, len(commit.Description()))
+		}
+	}
+
+	if rng, ok := d.partRange(name, commit); ok {
+		return rng
+	}
+	return d.lineRange(header)
+}
+
+// partRange returns the range of body or footer for body and footer rules
+func (d *document) partRange(name string, commit lint.Commit) (Range, bool) {
+	switch {
+	case strings.HasPrefix(name, "body-"):
+		if commit != nil {
+			return d.blockRange(commit.Body())
+		}
+	case strings.HasPrefix(name, "footer-"), strings.HasPrefix(name, "trailer-"),
+		name == "references-required", name == "signed-off-by":
+		if commit != nil {
+			if rng, ok := d.blockRange(commit.Footer()); ok {
+				return rng, true
+			}
+		}
+		// missing footer is shown at the end of message
+		return d.lineRange(d.last), true
+	}
+	return Range{}, false
+}
+
+// blockRange returns range from first to last line of block after header
+func (d *document) blockRange(block string) (Range, bool) {
+	block = strings.TrimSpace(block)
+	if block == "" {
+		return Range{}, false
+	}
+
+	blockLines := strings.Split(block, "\n")
+	start := d.findLine(blockLines[0], d.first+1)
+	if start < 0 {
+		return Range{}, false
+	}
+	end := d.findLine(blockLines[len(blockLines)-1], start)
+	if end < 0 {
+		end = start
+	}
+	return Range{Start: Position{Line: start}, End: d.lineRange(end).End}, true
+}
+
+// findLine returns the first content line from given line, same as s
+// ignoring trailing whitespace, -1 if not found
+func (d *document) findLine(s string, from int) int {
+	s = strings.TrimRight(s, " \t")
+	for i := from; i >= 0 && i <= d.last; i++ {
+		if !d.isComment(d.lines[i]) && strings.TrimRight(d.lines[i], " \t") == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// contentRange returns the range of message content
+func (d *document) contentRange() Range {
+	if d.first < 0 {
+		return Range{}
+	}
+	return Range{Start: Position{Line: d.first}, End: d.lineRange(d.last).End}
+}
+
+func (d *document) lineRange(line int) Range {
+	return d.span(line, 0, len(d.lines[line]))
+}
+
+// span returns the range of n bytes at byte offset off in line
+func (d *document) span(line, off, n int) Range {
+	text := d.lines[line]
+	return Range{
+		Start: Position{Line: line, Character: utf16Len(text[:off])},
+		End:   Position{Line: line, Character: utf16Len(text[:off+n])},
+	}
+}
+
+// linePrefix returns text of line before pos
+func (d *document) linePrefix(pos Position) string {
+	if pos.Line < 0 || pos.Line >= len(d.lines) {
+		return ""
+	}
+	line := d.lines[pos.Line]
+	return line[:byteOffset(line, pos.Character)]
+}
+
+// headerLine returns the line of header, first line if message is empty
+func (d *document) headerLine() int {
+	if d.first < 0 {
+		return 0
+	}
+	return d.first
+}
+
+// isFooterLine checks if line is in the last paragraph after header,
+// where footer tokens are written
+func (d *document) isFooterLine(line int) bool {
+	if d.first < 0 || line <= d.first+1 || line >= len(d.lines) {
+		return false
+	}
+	for i := line - 1; i > d.first; i-- {
+		if strings.TrimSpace(d.lines[i]) == "" {
+			return true
+		}
+		if !d.isComment(d.lines[i]) && !strings.Contains(d.lines[i], ":") {
+			return false
+		}
+	}
+	return false
+}
+
+// utf16Len returns length of s in utf-16 code units, used by lsp positions
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += len(utf16.Encode([]rune{r}))
+	}
+	return n
+}
+
+// byteOffset returns byte offset of utf-16 offset in s
+func byteOffset(s string, utf16Off int) int {
+	n := 0
+	for i, r := range s {
+		if n >= utf16Off {
+			return i
+		}
+		n += len(utf16.Encode([]rune{r}))
+	}
+	return len(s)
+}
+
+// runeOffset returns byte offset of n-th rune in s
+func runeOffset(s string, n int) int {
+	off := 0
+	for i := 0; i < n && off < len(s); i++ {
+		_, size := utf8.DecodeRuneInString(s[off:])
+		off += size
+	}
+	return off
+}
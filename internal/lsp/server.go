@@ -0,0 +1,516 @@
+// Package lsp is a language server for git commit message documents
+// it publishes lint issues as diagnostics, offers fixes as code actions
+// and completes types, scopes and footer tokens from the config
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/zexot-com/commitlint/config"
+	"github.com/zexot-com/commitlint/internal"
+	"github.com/zexot-com/commitlint/internal/git"
+	"github.com/zexot-com/commitlint/internal/scaffold"
+	"github.com/zexot-com/commitlint/lint"
+)
+
+// commitLanguages are the language ids editors use for commit messages
+var commitLanguages = []string{"git-commit", "gitcommit"}
+
+// commitFiles are the commit message files linted regardless of language id
+var commitFiles = []string{"COMMIT_EDITMSG", "MERGE_MSG", "SQUASH_MSG"}
+
+// configFilesGlob matches the config files looked up in workspace root
+const configFilesGlob = "**/{.commitlint,commitlint}.{yml,yaml}"
+
+// watcherRegistrationID is the id of config file watcher registration
+const watcherRegistrationID = "commitlint-config-watcher"
+
+// LoadFunc returns the config and path of config file, which is checked for
+// changes to reload the config. path is empty for default config
+type LoadFunc func() (conf *lint.Config, path string, err error)
+
+// Server is a language server communicating over given reader and writer
+type Server struct {
+	in  *bufio.Reader
+	out io.Writer
+
+	load LoadFunc
+	opts []lint.Option
+
+	linter   *lint.Linter
+	scaffold *scaffold.Scaffold
+
+	confPath    string
+	confModTime time.Time
+
+	// commentConfig is core.commentChar, read on config load instead of
+	// running git for every change
+	commentConfig string
+
+	// canWatchFiles is true if client supports registering file watchers
+	canWatchFiles bool
+	requestID     int
+
+	docs map[string]*document
+}
+
+// NewServer returns a language server, config is loaded with load
+// on initialize and when the config file changes. opts are used to
+// create the linter for loaded config
+func NewServer(in io.Reader, out io.Writer, load LoadFunc, opts ...lint.Option) *Server {
+	return &Server{
+		in:   bufio.NewReader(in),
+		out:  out,
+		load: load,
+		opts: opts,
+		docs: make(map[string]*document),
+	}
+}
+
+// Run serves requests until exit notification or end of input
+func (s *Server) Run() error {
+	for {
+		req, err := readRequest(s.in)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		var rpcErr *responseError
+		if errors.As(err, &rpcErr) {
+			err = writeMessage(s.out, &errorResponse{JSONRPC: "2.0", Error: rpcErr})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if req.Method == "exit" {
+			return nil
+		}
+
+		// responses to server requests like client/registerCapability are ignored
+		if req.Method == "" {
+			continue
+		}
+
+		err = s.handle(req)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// handle dispatches req and writes the response for requests
+// returned error is only for failures writing to client
+func (s *Server) handle(req *request) error {
+	result, err := s.dispatch(req)
+
+	if req.ID == nil {
+		if err != nil {
+			return s.notify("window/showMessage", showMessageParams{Type: messageTypeError, Message: "commitlint: " + err.Error()})
+		}
+		return nil
+	}
+
+	if err != nil {
+		var rpcErr *responseError
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &responseError{Code: codeInternalError, Message: err.Error()}
+		}
+		return writeMessage(s.out, &errorResponse{JSONRPC: "2.0", ID: req.ID, Error: rpcErr})
+	}
+	return writeMessage(s.out, &response{JSONRPC: "2.0", ID: req.ID, Result: result})
+}
+
+func (s *Server) dispatch(req *request) (interface{}, error) {
+	switch req.Method {
+	case "initialize":
+		var params initializeParams
+		if err := unmarshalParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.initialize(params)
+	case "initialized":
+		return nil, s.registerWatcher()
+	case "shutdown":
+		return nil, nil
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := unmarshalParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.didOpen(params)
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := unmarshalParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.didChange(params)
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err := unmarshalParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		delete(s.docs, params.TextDocument.URI)
+		return nil, s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: params.TextDocument.URI, Diagnostics: []Diagnostic{}})
+	case "workspace/didChangeWatchedFiles":
+		return nil, s.reload()
+	case "textDocument/codeAction":
+		var params codeActionParams
+		if err := unmarshalParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.codeActions(params)
+	case "textDocument/completion":
+		var params completionParams
+		if err := unmarshalParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.completion(params), nil
+	}
+
+	if req.ID == nil {
+		// unknown notifications like $/ notifications are ignored
+		return nil, nil
+	}
+	return nil, &responseError{Code: codeMethodNotFound, Message: "method not found: " + req.Method}
+}
+
+func unmarshalParams(params json.RawMessage, v interface{}) error {
+	if len(params) == 0 {
+		return nil
+	}
+	err := json.Unmarshal(params, v)
+	if err != nil {
+		return &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+func (s *Server) notify(method string, params interface{}) error {
+	return writeMessage(s.out, &notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+// request sends a request to client, its response is ignored
+func (s *Server) request(method string, params interface{}) error {
+	s.requestID++
+	return writeMessage(s.out, &serverRequest{JSONRPC: "2.0", ID: s.requestID, Method: method, Params: params})
+}
+
+// registerWatcher registers the config file watcher, so that clients send
+// workspace/didChangeWatchedFiles when config file is changed
+func (s *Server) registerWatcher() error {
+	if !s.canWatchFiles {
+		return nil
+	}
+
+	watchers := []fileSystemWatcher{{GlobPattern: configFilesGlob}}
+	if s.confPath != "" {
+		// config from env or flag can have any name
+		if path, err := filepath.Abs(s.confPath); err == nil {
+			watchers = append(watchers, fileSystemWatcher{GlobPattern: filepath.ToSlash(path)})
+		}
+	}
+
+	return s.request("client/registerCapability", registrationParams{
+		Registrations: []registration{{
+			ID:              watcherRegistrationID,
+			Method:          "workspace/didChangeWatchedFiles",
+			RegisterOptions: didChangeWatchedFilesRegistrationOptions{Watchers: watchers},
+		}},
+	})
+}
+
+func (s *Server) initialize(params initializeParams) (interface{}, error) {
+	// config is looked up in workspace root, editors may start the
+	// server in another directory
+	if dir := uriPath(params.RootURI); dir != "" {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			_ = os.Chdir(dir)
+		}
+	}
+
+	s.canWatchFiles = params.Capabilities.Workspace.DidChangeWatchedFiles.DynamicRegistration
+
+	err := s.loadConfig()
+	if err != nil {
+		if s.linter == nil {
+			return nil, err
+		}
+		// default config is used, editor should still get diagnostics
+		err = s.notify("window/showMessage", showMessageParams{Type: messageTypeError, Message: "commitlint: " + err.Error()})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return map[string]interface{}{
+		"capabilities": map[string]interface{}{
+			"textDocumentSync":   map[string]interface{}{"openClose": true, "change": syncFull},
+			"completionProvider": map[string]interface{}{"triggerCharacters": []string{"("}},
+			"codeActionProvider": map[string]interface{}{"codeActionKinds": []string{codeActionQuickFix}},
+		},
+		"serverInfo": map[string]string{"name": "commitlint", "version": internal.Version()},
+	}, nil
+}
+
+// loadConfig loads the config and creates linter, on error the
+// previous linter or the default config is used
+func (s *Server) loadConfig() error {
+	conf, path, loadErr := s.load()
+	if loadErr == nil {
+		loadErr = s.setConfig(conf)
+	}
+
+	s.confPath = path
+	s.confModTime = modTime(path)
+	s.commentConfig = commentConfig()
+
+	if loadErr != nil && s.linter == nil {
+		if err := s.setConfig(config.NewDefault()); err != nil {
+			return err
+		}
+	}
+	return loadErr
+}
+
+// commentConfig returns core.commentChar, empty if git config can not be read
+func commentConfig() string {
+	char, err := git.Config("core.commentChar")
+	if err != nil {
+		return ""
+	}
+	return char
+}
+
+func (s *Server) setConfig(conf *lint.Config) error {
+	linter, err := config.NewLinter(conf, s.opts...)
+	if err != nil {
+		return err
+	}
+
+	rules, err := config.GetEnabledRules(conf)
+	if err != nil {
+		return err
+	}
+
+	s.linter = linter
+	s.scaffold = scaffold.New(rules)
+	return nil
+}
+
+// reload loads the config and lints the open documents again
+func (s *Server) reload() error {
+	loadErr := s.loadConfig()
+
+	for _, doc := range s.docs {
+		err := s.lintAndPublish(doc)
+		if err != nil {
+			return err
+		}
+	}
+	return loadErr
+}
+
+// reloadIfChanged reloads the config if config file is changed after load
+// returns true if reloaded, open documents are linted by reload
+func (s *Server) reloadIfChanged() (bool, error) {
+	if s.confPath == "" || modTime(s.confPath).Equal(s.confModTime) {
+		return false, nil
+	}
+	return true, s.reload()
+}
+
+func modTime(path string) time.Time {
+	if path == "" {
+		return time.Time{}
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+func (s *Server) didOpen(params didOpenParams) error {
+	item := params.TextDocument
+	if !isCommitDocument(item.URI, item.LanguageID) {
+		return nil
+	}
+
+	doc := newDocument(item.URI, item.Text, s.commentConfig)
+	s.docs[item.URI] = doc
+
+	if reloaded, err := s.reloadIfChanged(); reloaded {
+		return err
+	}
+	return s.lintAndPublish(doc)
+}
+
+func (s *Server) didChange(params didChangeParams) error {
+	uri := params.TextDocument.URI
+	if _, ok := s.docs[uri]; !ok || len(params.ContentChanges) == 0 {
+		return nil
+	}
+
+	// full sync, last change has the whole text
+	doc := newDocument(uri, params.ContentChanges[len(params.ContentChanges)-1].Text, s.commentConfig)
+	s.docs[uri] = doc
+
+	if reloaded, err := s.reloadIfChanged(); reloaded {
+		return err
+	}
+	return s.lintAndPublish(doc)
+}
+
+func (s *Server) lintAndPublish(doc *document) error {
+	lintErr := doc.lint(s.linter)
+
+	err := s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: doc.uri, Diagnostics: doc.diagnostics()})
+	if err != nil {
+		return err
+	}
+	return lintErr
+}
+
+func (s *Server) codeActions(params codeActionParams) ([]codeAction, error) {
+	actions := []codeAction{}
+
+	doc, ok := s.docs[params.TextDocument.URI]
+	if !ok {
+		return actions, nil
+	}
+
+	hasFix := false
+	for _, i := range doc.issues {
+		if i.issue.Fix() == "" {
+			continue
+		}
+		hasFix = true
+
+		if !overlaps(i.rng, params.Range) {
+			continue
+		}
+		actions = append(actions, codeAction{
+			Title:       "Fix " + i.issue.RuleName() + ": " + i.issue.Description(),
+			Kind:        codeActionQuickFix,
+			Diagnostics: []Diagnostic{newDiagnostic(i)},
+			Edit:        doc.replaceEdit(i.issue.Fix()),
+			IsPreferred: true,
+		})
+	}
+
+	if !hasFix {
+		return actions, nil
+	}
+
+	fixed, err := s.linter.Fix(doc.msg)
+	if err != nil {
+		return nil, err
+	}
+	if fixed != doc.msg {
+		actions = append(actions, codeAction{
+			Title: "Fix all commitlint issues",
+			Kind:  codeActionQuickFix,
+			Edit:  doc.replaceEdit(fixed),
+		})
+	}
+	return actions, nil
+}
+
+// replaceEdit returns the edit replacing message content with msg
+func (d *document) replaceEdit(msg string) workspaceEdit {
+	edit := textEdit{Range: d.contentRange(), NewText: strings.TrimRight(msg, "\n")}
+	return workspaceEdit{Changes: map[string][]textEdit{d.uri: {edit}}}
+}
+
+func overlaps(a, b Range) bool {
+	return a.Start.Line <= b.End.Line && b.Start.Line <= a.End.Line
+}
+
+func (s *Server) completion(params completionParams) []completionItem {
+	items := []completionItem{}
+
+	doc, ok := s.docs[params.TextDocument.URI]
+	if !ok {
+		return items
+	}
+
+	line := params.Position.Line
+	prefix := doc.linePrefix(params.Position)
+
+	switch {
+	case line == doc.headerLine() && scopePrefix.MatchString(prefix):
+		for _, scope := range s.scaffold.Scopes {
+			items = append(items, completionItem{Label: scope, Kind: completionKindEnum, Detail: "scope"})
+		}
+	case line == doc.headerLine() && tokenPrefix.MatchString(prefix):
+		for _, typ := range s.scaffold.Types {
+			items = append(items, completionItem{Label: typ, Kind: completionKindKeyword, Detail: "type"})
+		}
+	case doc.isFooterLine(line) && tokenPrefix.MatchString(prefix):
+		items = s.footerItems()
+	}
+	return items
+}
+
+// footerItems returns footer tokens required by footer-type-enum and the
+// reference token
+func (s *Server) footerItems() []completionItem {
+	var items []completionItem
+	added := map[string]bool{}
+
+	for _, f := range s.scaffold.Footers {
+		if added[f.Token] {
+			continue
+		}
+		added[f.Token] = true
+
+		detail := "footer"
+		if len(f.Types) > 0 {
+			detail = "footer for " + strings.Join(f.Types, ", ")
+		}
+		items = append(items, completionItem{Label: f.Token, Kind: completionKindField, Detail: detail, InsertText: f.Token + ": "})
+	}
+
+	if !added[s.scaffold.RefToken] {
+		items = append(items, completionItem{Label: s.scaffold.RefToken, Kind: completionKindField, Detail: "reference footer", InsertText: s.scaffold.RefToken + ": "})
+	}
+	return items
+}
+
+func isCommitDocument(uri, languageID string) bool {
+	for _, lang := range commitLanguages {
+		if languageID == lang {
+			return true
+		}
+	}
+
+	name := filepath.Base(uriPath(uri))
+	for _, f := range commitFiles {
+		if name == f {
+			return true
+		}
+	}
+	return false
+}
+
+// uriPath returns the file path of file uri, empty for other uris
+func uriPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return ""
+	}
+	return filepath.FromSlash(u.Path)
+}
@@ -0,0 +1,257 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/zexot-com/commitlint/config"
+	"github.com/zexot-com/commitlint/lint"
+)
+
+const testConfig = `version: v0.1.0
+rules: [type-enum, scope-enum]
+settings:
+  type-enum:
+    argument: [feat, fix]
+  scope-enum:
+    argument: [api, web]
+`
+
+type testClient struct {
+	t   *testing.T
+	in  *io.PipeWriter
+	out *bufio.Reader
+	id  int
+}
+
+func newTestClient(t *testing.T, confPath string) *testClient {
+	t.Helper()
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	load := func() (*lint.Config, string, error) {
+		conf, err := config.Parse(confPath)
+		return conf, confPath, err
+	}
+
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	go func() {
+		_ = NewServer(inR, outW, load).Run()
+		outW.Close()
+	}()
+	t.Cleanup(func() { inW.Close() })
+
+	return &testClient{t: t, in: inW, out: bufio.NewReader(outR)}
+}
+
+func (c *testClient) send(method string, params interface{}, isRequest bool) {
+	c.t.Helper()
+
+	msg := map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params}
+	if isRequest {
+		c.id++
+		msg["id"] = c.id
+	}
+	err := writeMessage(c.in, msg)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// receive reads the next message from server into v
+func (c *testClient) receive(v interface{}) {
+	c.t.Helper()
+
+	header, err := textproto.NewReader(c.out).ReadMIMEHeader()
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	length, _ := strconv.Atoi(header.Get("Content-Length"))
+	body := make([]byte, length)
+	_, err = io.ReadFull(c.out, body)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	err = json.Unmarshal(body, v)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+func (c *testClient) diagnostics() []Diagnostic {
+	c.t.Helper()
+
+	var msg struct {
+		Method string                   `json:"method"`
+		Params publishDiagnosticsParams `json:"params"`
+	}
+	c.receive(&msg)
+	if msg.Method != "textDocument/publishDiagnostics" {
+		c.t.Fatalf("got %s, want publishDiagnostics", msg.Method)
+	}
+	return msg.Params.Diagnostics
+}
+
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	err := os.WriteFile(path, []byte(content), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServer(t *testing.T) {
+	confPath := filepath.Join(t.TempDir(), ".commitlint.yaml")
+	writeConfig(t, confPath, testConfig)
+
+	c := newTestClient(t, confPath)
+	c.send("initialize", map[string]interface{}{
+		"capabilities": map[string]interface{}{
+			"workspace": map[string]interface{}{"didChangeWatchedFiles": map[string]interface{}{"dynamicRegistration": true}},
+		},
+	}, true)
+	var initResult struct {
+		Result struct {
+			Capabilities map[string]interface{} `json:"capabilities"`
+		} `json:"result"`
+	}
+	c.receive(&initResult)
+	if _, ok := initResult.Result.Capabilities["codeActionProvider"]; !ok {
+		t.Errorf("missing codeActionProvider capability in %v", initResult.Result.Capabilities)
+	}
+	c.send("initialized", map[string]interface{}{}, false)
+
+	var register struct {
+		ID     int                `json:"id"`
+		Method string             `json:"method"`
+		Params registrationParams `json:"params"`
+	}
+	c.receive(&register)
+	if register.Method != "client/registerCapability" || len(register.Params.Registrations) != 1 ||
+		register.Params.Registrations[0].Method != "workspace/didChangeWatchedFiles" {
+		t.Fatalf("unexpected registration %+v", register)
+	}
+	// response to registration is ignored by server
+	err := writeMessage(c.in, map[string]interface{}{"jsonrpc": "2.0", "id": register.ID, "result": nil})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uri := "file:///repo/.git/COMMIT_EDITMSG"
+	text := "Feat(ui): add login\n\n# Please enter the commit message\n"
+	c.send("textDocument/didOpen", map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": uri, "languageId": "git-commit", "version": 1, "text": text},
+	}, false)
+
+	diags := c.diagnostics()
+	ranges := map[string]Range{}
+	for _, d := range diags {
+		ranges[d.Code] = d.Range
+	}
+	wantRanges := map[string]Range{
+		"type-enum":  {Start: Position{0, 0}, End: Position{0, 4}},
+		"scope-enum": {Start: Position{0, 5}, End: Position{0, 7}},
+	}
+	for code, want := range wantRanges {
+		if got, ok := ranges[code]; !ok || got != want {
+			t.Errorf("%s range = %+v, want %+v in %+v", code, got, want, diags)
+		}
+	}
+
+	c.send("textDocument/codeAction", map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": uri},
+		"range":        Range{Start: Position{0, 0}, End: Position{0, 0}},
+	}, true)
+	var actions struct {
+		Result []codeAction `json:"result"`
+	}
+	c.receive(&actions)
+	var fixAll *codeAction
+	for i := range actions.Result {
+		if actions.Result[i].Title == "Fix all commitlint issues" {
+			fixAll = &actions.Result[i]
+		}
+	}
+	if fixAll == nil {
+		t.Fatalf("missing fix all action in %+v", actions.Result)
+	}
+	edit := fixAll.Edit.Changes[uri][0]
+	if edit.NewText != "feat(ui): add login" || edit.Range.End != (Position{0, 19}) {
+		t.Errorf("unexpected fix edit %+v", edit)
+	}
+
+	c.send("textDocument/didChange", map[string]interface{}{
+		"textDocument":   map[string]interface{}{"uri": uri, "version": 2},
+		"contentChanges": []map[string]string{{"text": "feat(a\n"}},
+	}, false)
+	c.diagnostics()
+
+	c.send("textDocument/completion", map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": uri},
+		"position":     Position{0, 6},
+	}, true)
+	var completion struct {
+		Result []completionItem `json:"result"`
+	}
+	c.receive(&completion)
+	if len(completion.Result) != 2 || completion.Result[0].Label != "api" {
+		t.Errorf("unexpected scope completion %+v", completion.Result)
+	}
+
+	c.send("textDocument/didChange", map[string]interface{}{
+		"textDocument":   map[string]interface{}{"uri": uri, "version": 3},
+		"contentChanges": []map[string]string{{"text": "feat(api): add login\n\nRe"}},
+	}, false)
+	c.diagnostics()
+
+	c.send("textDocument/completion", map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": uri},
+		"position":     Position{2, 2},
+	}, true)
+	c.receive(&completion)
+	if len(completion.Result) != 1 || completion.Result[0].InsertText != "Refs: " {
+		t.Errorf("unexpected footer completion %+v", completion.Result)
+	}
+
+	// config changes are picked up on next change
+	writeConfig(t, confPath, "version: v0.1.0\nrules: [type-enum]\nsettings:\n  type-enum:\n    argument: [Feat]\n")
+	future := time.Now().Add(time.Minute)
+	err = os.Chtimes(confPath, future, future)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.send("textDocument/didChange", map[string]interface{}{
+		"textDocument":   map[string]interface{}{"uri": uri, "version": 4},
+		"contentChanges": []map[string]string{{"text": text}},
+	}, false)
+	if diags := c.diagnostics(); len(diags) != 0 {
+		t.Errorf("got %+v after config reload, want no diagnostics", diags)
+	}
+
+	c.send("shutdown", nil, true)
+	var shutdown map[string]interface{}
+	c.receive(&shutdown)
+	if _, ok := shutdown["result"]; !ok {
+		t.Errorf("shutdown response has no result: %v", shutdown)
+	}
+	c.send("exit", nil, false)
+}
+
+func TestUTF16(t *testing.T) {
+	s := "feat: add 😀 emoji"
+	if got := utf16Len(s); got != 18 {
+		t.Errorf("utf16Len = %d, want 18", got)
+	}
+	if got := byteOffset(s, 12); got != len("feat: add 😀") {
+		t.Errorf("byteOffset = %d, want %d", got, len("feat: add 😀"))
+	}
+}
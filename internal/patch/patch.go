@@ -0,0 +1,242 @@
+// Package patch extracts commit messages from patch files created by
+// 'git format-patch' and from mbox files used by 'git am'
+package patch
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+)
+
+// mboxFromLine matches the line which starts a message in mbox, like
+// 'From 6c2d1e0a9f Mon Sep 17 00:00:00 2001' added by format-patch. Date
+// is matched same as 'git mailsplit', as format-patch does not escape
+// body lines like 'From now on ...'
+var mboxFromLine = regexp.MustCompile(`^From \S+ +\w{3} \w{3} [ \d]\d \d\d:\d\d:\d\d \d{4}$`)
+
+// escapedFromLine matches body lines starting with 'From ', escaped with '>'
+// in mbox, like '>From the start'
+var escapedFromLine = regexp.MustCompile(`^>+From `)
+
+// inBodyHeaders are headers which may start the body to override the mail
+// headers, added by format-patch when author is not the sender
+var inBodyHeaders = []string{"From", "Subject", "Date"}
+
+// Patch represent a commit message extracted from a patch
+type Patch struct {
+	// File is the name of file containing the patch
+	File string
+
+	// Index is the position of patch in file, starting from 1
+	Index int
+
+	// Hash is the commit hash from mbox 'From' line, if any
+	Hash string
+
+	AuthorName  string
+	AuthorEmail string
+
+	// Message is the subject, without '[PATCH n/m]' prefix, and
+	// the body up to '---' separator
+	Message string
+}
+
+// Key returns the file and index identifying the patch, like '0001-fix.patch:1'
+func (p *Patch) Key() string {
+	return fmt.Sprintf("%s:%d", p.File, p.Index)
+}
+
+// Parse returns the patches in r, which is a single patch or an mbox
+// with multiple patches. name is used as File of returned patches
+func Parse(name string, r io.Reader) ([]*Patch, error) {
+	var patches []*Patch
+	var lines []string
+	var hash string
+
+	addPatch := func() {
+		if p := parseMessage(lines); p != nil {
+			p.File, p.Index, p.Hash = name, len(patches)+1, hash
+			patches = append(patches, p)
+		}
+		lines, hash = nil, ""
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	prevEmpty := true
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if prevEmpty && mboxFromLine.MatchString(line) {
+			addPatch()
+			hash = strings.Fields(line)[1]
+			prevEmpty = false
+			continue
+		}
+		prevEmpty = line == ""
+		if escapedFromLine.MatchString(line) {
+			line = line[1:]
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	addPatch()
+
+	if len(patches) == 0 {
+		return nil, fmt.Errorf("no patch found in %s", name)
+	}
+	return patches, nil
+}
+
+// parseMessage returns the patch of mail lines, nil if there is no subject
+func parseMessage(lines []string) *Patch {
+	headers, rest := parseHeaders(lines)
+	if len(rest) == len(lines) {
+		return nil
+	}
+
+	// 'git send-email' encodes non-ASCII body, in-body headers are encoded too
+	rest = decodeBody(rest, headers["content-transfer-encoding"])
+
+	// in-body headers are followed by an empty line like mail headers
+	if len(rest) > 0 && isInBodyHeader(rest[0]) {
+		inBody, body := parseHeaders(rest)
+		for key, value := range inBody {
+			headers[key] = value
+		}
+		rest = body
+	}
+
+	subject, ok := headers["subject"]
+	if !ok {
+		return nil
+	}
+
+	p := &Patch{}
+	p.AuthorName, p.AuthorEmail = parseAuthor(headers["from"])
+
+	msg := cleanupSubject(decodeHeader(subject))
+	if body := strings.TrimSpace(strings.Join(messageBody(rest), "\n")); body != "" {
+		msg += "\n\n" + body
+	}
+	p.Message = msg
+	return p
+}
+
+// parseHeaders returns the unfolded headers, keyed by lowercase name,
+// and the lines after the empty line ending headers
+func parseHeaders(lines []string) (map[string]string, []string) {
+	headers := map[string]string{}
+	var key string
+
+	for i, line := range lines {
+		if line == "" {
+			return headers, lines[i+1:]
+		}
+
+		// folded header continues on lines starting with whitespace
+		if line[0] == ' ' || line[0] == '\t' {
+			if key != "" {
+				headers[key] += " " + strings.TrimSpace(line)
+			}
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		if !ok || strings.ContainsAny(name, " \t") {
+			return headers, lines[i:]
+		}
+		key = strings.ToLower(name)
+		headers[key] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
+func isInBodyHeader(line string) bool {
+	for _, h := range inBodyHeaders {
+		if strings.HasPrefix(line, h+":") {
+			return true
+		}
+	}
+	return false
+}
+
+// messageBody returns the body lines up to '---' separator or the diff
+func messageBody(lines []string) []string {
+	for i, line := range lines {
+		if line == "---" || strings.HasPrefix(line, "diff --git ") || strings.HasPrefix(line, "Index: ") {
+			return lines[:i]
+		}
+	}
+	return lines
+}
+
+// cleanupSubject removes 'Re:' and bracketed prefixes like '[PATCH v2 1/3]'
+// same as 'git am' does
+func cleanupSubject(subject string) string {
+	subject = strings.Join(strings.Fields(subject), " ")
+	for {
+		switch {
+		case strings.HasPrefix(strings.ToLower(subject), "re:"):
+			subject = strings.TrimSpace(subject[3:])
+		case strings.HasPrefix(subject, "["):
+			end := strings.Index(subject, "]")
+			if end < 0 {
+				return subject
+			}
+			subject = strings.TrimSpace(subject[end+1:])
+		default:
+			return subject
+		}
+	}
+}
+
+// decodeBody returns the body lines decoded with given Content-Transfer-Encoding
+// lines are returned as is for other encodings or invalid encoded body
+func decodeBody(lines []string, encoding string) []string {
+	var decoded []byte
+	var err error
+
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		r := quotedprintable.NewReader(strings.NewReader(strings.Join(lines, "\n")))
+		decoded, err = io.ReadAll(r)
+	case "base64":
+		// line breaks and padding spaces are not part of encoded data
+		data := strings.Join(strings.Fields(strings.Join(lines, "")), "")
+		decoded, err = base64.StdEncoding.DecodeString(data)
+	default:
+		return lines
+	}
+	if err != nil {
+		return lines
+	}
+	return strings.Split(strings.ReplaceAll(string(decoded), "\r\n", "\n"), "\n")
+}
+
+// decodeHeader decodes RFC 2047 encoded words, like '=?UTF-8?q?...?='
+func decodeHeader(value string) string {
+	decoded, err := new(mime.WordDecoder).DecodeHeader(value)
+	if err != nil {
+		return value
+	}
+	return decoded
+}
+
+func parseAuthor(from string) (name, email string) {
+	if from == "" {
+		return "", ""
+	}
+	addr, err := mail.ParseAddress(from)
+	if err != nil {
+		return decodeHeader(from), ""
+	}
+	return addr.Name, addr.Address
+}
@@ -0,0 +1,166 @@
+package patch
+
+import (
+	"strings"
+	"testing"
+)
+
+const series = `From 1111111111111111111111111111111111111111 Mon Sep 17 00:00:00 2001
+From: Jane Doe <jane@example.com>
+Date: Mon, 1 Jan 2024 10:00:00 +0000
+Subject: [PATCH v2 1/2] feat(api): add login endpoint with a long
+ subject folded by format-patch
+
+Adds the login endpoint.
+
+Refs: PAY-42
+---
+ api/login.go | 1 +
+ 1 file changed, 1 insertion(+)
+
+diff --git a/api/login.go b/api/login.go
+--- a/api/login.go
++++ b/api/login.go
+@@ -0,0 +1 @@
++package api
+--
+2.43.0
+
+From 2222222222222222222222222222222222222222 Mon Sep 17 00:00:00 2001
+From: Sender <sender@example.com>
+Date: Mon, 1 Jan 2024 10:00:00 +0000
+Subject: [PATCH v2 2/2] =?UTF-8?q?fix:=20caf=C3=A9?=
+
+From: John Roe <john@example.com>
+
+bad body
+diff --git a/a b/a
+`
+
+func TestParse(t *testing.T) {
+	patches, err := Parse("series.mbox", strings.NewReader(series))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patches) != 2 {
+		t.Fatalf("got %d patches, want 2", len(patches))
+	}
+
+	first := patches[0]
+	wantMsg := "feat(api): add login endpoint with a long subject folded by format-patch\n\nAdds the login endpoint.\n\nRefs: PAY-42"
+	if first.Message != wantMsg {
+		t.Errorf("Message = %q, want %q", first.Message, wantMsg)
+	}
+	if first.Key() != "series.mbox:1" || first.Hash != strings.Repeat("1", 40) {
+		t.Errorf("unexpected key %s and hash %s", first.Key(), first.Hash)
+	}
+	if first.AuthorName != "Jane Doe" || first.AuthorEmail != "jane@example.com" {
+		t.Errorf("unexpected author %s <%s>", first.AuthorName, first.AuthorEmail)
+	}
+
+	second := patches[1]
+	if second.Message != "fix: café\n\nbad body" {
+		t.Errorf("Message = %q", second.Message)
+	}
+	if second.Index != 2 || second.AuthorName != "John Roe" {
+		t.Errorf("unexpected index %d and author %s", second.Index, second.AuthorName)
+	}
+}
+
+func TestParseEncodedBody(t *testing.T) {
+	tests := []struct {
+		name   string
+		patch  string
+		msg    string
+		author string
+	}{
+		{
+			name: "quoted-printable",
+			patch: "From: Jane Doe <jane@example.com>\nSubject: [PATCH] fix: handle accents\n" +
+				"Content-Type: text/plain; charset=UTF-8\nContent-Transfer-Encoding: quoted-printable\n\n" +
+				"Names like Zo=C3=AB are now sorted correctly, the line is long and soft wra=\npped.\n---\n a | 1 +\n",
+			msg:    "fix: handle accents\n\nNames like Zoë are now sorted correctly, the line is long and soft wrapped.",
+			author: "Jane Doe",
+		},
+		{
+			name: "base64 with in-body header",
+			patch: "From: Sender <sender@example.com>\nSubject: [PATCH] feat: accents\n" +
+				"Content-Type: text/plain; charset=UTF-8\nContent-Transfer-Encoding: base64\n\n" +
+				"RnJvbTogWm/DqyBSb2UgPHpvZUBleGFtcGxlLmNvbT4KCkFqb3V0ZSBsYSBwcmlzZSBlbiBjaGFy\n" +
+				"Z2UgZGUgbCdhY2NlbnR1w6kuCi0tLQogYSB8IDEgKwo=\n",
+			msg:    "feat: accents\n\nAjoute la prise en charge de l'accentué.",
+			author: "Zoë Roe",
+		},
+		{
+			name: "escaped from lines",
+			patch: "From 1111111111111111111111111111111111111111 Mon Sep 17 00:00:00 2001\n" +
+				"From: Jane Doe <jane@example.com>\nSubject: [PATCH] docs: quote mail\n\n" +
+				">From the start it was wrong.\n>>From here quoted.\n---\n",
+			msg:    "docs: quote mail\n\nFrom the start it was wrong.\n>From here quoted.",
+			author: "Jane Doe",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			patches, err := Parse("test.patch", strings.NewReader(tc.patch))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(patches) != 1 {
+				t.Fatalf("got %d patches, want 1", len(patches))
+			}
+			if patches[0].Message != tc.msg {
+				t.Errorf("Message = %q, want %q", patches[0].Message, tc.msg)
+			}
+			if patches[0].AuthorName != tc.author {
+				t.Errorf("AuthorName = %q, want %q", patches[0].AuthorName, tc.author)
+			}
+		})
+	}
+}
+
+func TestParseFromInBody(t *testing.T) {
+	mbox := "From 1111111111111111111111111111111111111111 Mon Sep 17 00:00:00 2001\n" +
+		"From: Jane Doe <jane@example.com>\nSubject: [PATCH] fix: keep sessions\n\n" +
+		"Sessions were dropped on restart.\n\nFrom now on they are stored.\n\nRefs: PAY-7\n---\n a | 1 +\n\n" +
+		"From 2222222222222222222222222222222222222222 Mon Jan  1 09:05:00 2024\n" +
+		"From: Jane Doe <jane@example.com>\nSubject: [PATCH] docs: add guide\n\n---\n"
+
+	patches, err := Parse("series.mbox", strings.NewReader(mbox))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patches) != 2 {
+		t.Fatalf("got %d patches, want 2", len(patches))
+	}
+	want := "fix: keep sessions\n\nSessions were dropped on restart.\n\nFrom now on they are stored.\n\nRefs: PAY-7"
+	if patches[0].Message != want {
+		t.Errorf("Message = %q, want %q", patches[0].Message, want)
+	}
+	if patches[1].Message != "docs: add guide" || patches[1].Hash != strings.Repeat("2", 40) {
+		t.Errorf("unexpected second patch %+v", patches[1])
+	}
+}
+
+func TestCleanupSubject(t *testing.T) {
+	tests := map[string]string{
+		"[PATCH] fix: typo":             "fix: typo",
+		"[PATCH 3/5] fix: typo":         "fix: typo",
+		"Re: [RFC PATCH v3 01/12] feat": "feat",
+		"[PATCH][net-next]  docs:  x":   "docs: x",
+		"fix: keep [brackets]":          "fix: keep [brackets]",
+	}
+	for subject, want := range tests {
+		if got := cleanupSubject(subject); got != want {
+			t.Errorf("cleanupSubject(%q) = %q, want %q", subject, got, want)
+		}
+	}
+}
+
+func TestParseNoPatch(t *testing.T) {
+	_, err := Parse("empty.patch", strings.NewReader("feat: plain message\n"))
+	if err == nil {
+		t.Error("expected error for input without patch")
+	}
+}
@@ -0,0 +1,52 @@
+package push
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/zexot-com/commitlint/internal/git"
+)
+
+// ParsePush parses '<local ref> <local sha> <remote ref> <remote sha>' lines
+// of pre-push hook stdin. Ref of returned updates is the remote ref
+func ParsePush(r io.Reader) ([]Update, error) {
+	var updates []Update
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("invalid pre-push input line '%s', expected '<local ref> <local sha> <remote ref> <remote sha>'", line)
+		}
+		updates = append(updates, Update{Ref: fields[2], Old: fields[3], New: fields[1]})
+	}
+	return updates, scanner.Err()
+}
+
+// OutgoingCommits returns the commits of update which are not yet on remote,
+// those reachable from remote sha or remote tracking refs are excluded.
+// Empty remote excludes the tracking refs of all remotes
+func OutgoingCommits(u Update, remote string, log CommitLog) ([]*git.Commit, error) {
+	if u.IsDelete() {
+		return nil, nil
+	}
+
+	remotes := "--remotes"
+	if remote != "" {
+		remotes += "=" + remote
+	}
+
+	// remote sha is missing locally if remote has commits not yet fetched
+	args := []string{"--ignore-missing", u.New, "--not", remotes}
+	if !u.IsCreate() {
+		args = append(args, u.Old)
+	}
+	return log(args...)
+}
@@ -0,0 +1,224 @@
+// Package push lints the commits pushed to refs in pre-receive, update and pre-push hooks
+package push
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/zexot-com/commitlint/internal/git"
+	"github.com/zexot-com/commitlint/lint"
+)
+
+// Ref Policy Actions for commits with errors
+const (
+	// ActionReject rejects the push, default action
+	ActionReject = "reject"
+
+	// ActionWarn prints the issues and accepts the push
+	ActionWarn = "warn"
+
+	// ActionSkip does not lint the commits
+	ActionSkip = "skip"
+)
+
+// RefPolicy represent the lint policy for pushed refs
+type RefPolicy struct {
+	// Refs are ref name patterns like 'refs/heads/main' or 'refs/heads/release/*'
+	// '*' matches any chars including '/', same as git refspecs
+	Refs []string `yaml:"refs"`
+
+	// Action for commits with errors, reject, warn or skip
+	Action string `yaml:"action"`
+}
+
+// Config represent config for pre-receive command
+type Config struct {
+	// Policies are checked in order, first policy matching the ref is used
+	Policies []RefPolicy `yaml:"policies,omitempty"`
+
+	// Default action for refs not matching any policy, defaults to reject
+	Default string `yaml:"default,omitempty"`
+}
+
+// Update represent a ref update, as read from pre-receive stdin
+type Update struct {
+	Ref string
+	Old string
+	New string
+}
+
+// IsCreate returns true if the ref is created by the push
+func (u Update) IsCreate() bool { return isZeroHash(u.Old) }
+
+// IsDelete returns true if the ref is deleted by the push
+func (u Update) IsDelete() bool { return isZeroHash(u.New) }
+
+// isZeroHash checks for the all zero hash git uses for missing refs
+func isZeroHash(hash string) bool {
+	return hash != "" && strings.Trim(hash, "0") == ""
+}
+
+// ParseReceive parses '<old> <new> <ref>' lines of pre-receive hook stdin
+func ParseReceive(r io.Reader) ([]Update, error) {
+	var updates []Update
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid pre-receive input line '%s', expected '<old> <new> <ref>'", line)
+		}
+		updates = append(updates, Update{Old: fields[0], New: fields[1], Ref: fields[2]})
+	}
+	return updates, scanner.Err()
+}
+
+// CheckConfig checks the policy actions
+func CheckConfig(conf Config) error {
+	if !isValidAction(conf.Default) {
+		return fmt.Errorf("unknown pre-receive default action '%s'", conf.Default)
+	}
+
+	for _, p := range conf.Policies {
+		if !isValidAction(p.Action) {
+			return fmt.Errorf("unknown pre-receive policy action '%s'", p.Action)
+		}
+	}
+	return nil
+}
+
+func isValidAction(action string) bool {
+	switch action {
+	case "", ActionReject, ActionWarn, ActionSkip:
+		return true
+	}
+	return false
+}
+
+// Action returns the action of first policy matching ref
+// defaults to reject if no policy matches
+func Action(conf Config, ref string) string {
+	for _, p := range conf.Policies {
+		for _, pattern := range p.Refs {
+			if matchRef(pattern, ref) {
+				return withDefault(p.Action)
+			}
+		}
+	}
+	return withDefault(conf.Default)
+}
+
+// matchRef checks if ref matches pattern, '*' matches any chars including
+// '/' like git refspecs, so 'refs/heads/*' matches 'refs/heads/feature/x'
+func matchRef(pattern, ref string) bool {
+	re := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+	ok, _ := regexp.MatchString(re, ref)
+	return ok
+}
+
+func withDefault(action string) string {
+	if action == "" {
+		return ActionReject
+	}
+	return action
+}
+
+// CommitLog returns the commits selected by git log args
+type CommitLog func(args ...string) ([]*git.Commit, error)
+
+// NewCommits returns the commits of update which are not reachable from
+// any existing ref, so commits already in the repository are not linted
+func NewCommits(u Update, log CommitLog) ([]*git.Commit, error) {
+	if u.IsDelete() {
+		return nil, nil
+	}
+	return log(u.New, "--not", "--all")
+}
+
+// CommitResult represent the lint result of a commit
+type CommitResult struct {
+	Commit *git.Commit
+	Result *lint.Result
+}
+
+// RefResult represent the lint results of commits pushed to a ref
+type RefResult struct {
+	Ref    string
+	Action string
+
+	// Total is the number of linted commits
+	Total int
+
+	// Failed are the commits with error severity issues
+	Failed []*CommitResult
+}
+
+// IsRejected returns true if the push to ref should be rejected
+func (r *RefResult) IsRejected() bool {
+	return r.Action == ActionReject && len(r.Failed) > 0
+}
+
+// Lint lints the commits pushed to ref
+func Lint(linter *lint.Linter, ref, action string, commits []*git.Commit) (*RefResult, error) {
+	res := &RefResult{Ref: ref, Action: action, Total: len(commits)}
+
+	for _, commit := range commits {
+		author := lint.Signature{Name: commit.AuthorName, Email: commit.AuthorEmail}
+		result, err := linter.ParseAndLintAuthored(commit.Message, author)
+		if err != nil {
+			return nil, err
+		}
+
+		if hasErrors(result) {
+			res.Failed = append(res.Failed, &CommitResult{Commit: commit, Result: result})
+		}
+	}
+	return res, nil
+}
+
+func hasErrors(result *lint.Result) bool {
+	for _, issue := range result.Issues() {
+		if issue.Severity() == lint.SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Format returns the compact report of refs with failed commits
+// each failed commit is shown with its header and the error issues
+func Format(results []*RefResult) string {
+	w := &strings.Builder{}
+
+	for _, res := range results {
+		if len(res.Failed) == 0 {
+			continue
+		}
+
+		if res.IsRejected() {
+			fmt.Fprintf(w, "✖ %s: rejected, %d of %d commits do not follow commit conventions\n", res.Ref, len(res.Failed), res.Total)
+		} else {
+			fmt.Fprintf(w, "⚠ %s: %d of %d commits do not follow commit conventions\n", res.Ref, len(res.Failed), res.Total)
+		}
+
+		for _, failed := range res.Failed {
+			header, _, _ := strings.Cut(failed.Result.Input(), "\n")
+			fmt.Fprintf(w, "  %s %s\n", failed.Commit.ShortHash(), header)
+			for _, issue := range failed.Result.Issues() {
+				if issue.Severity() != lint.SeverityError {
+					continue
+				}
+				fmt.Fprintf(w, "    - %s: %s\n", issue.RuleName(), issue.Description())
+			}
+		}
+	}
+	return w.String()
+}
@@ -0,0 +1,168 @@
+package push
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zexot-com/commitlint/internal/git"
+	"github.com/zexot-com/commitlint/lint"
+	"github.com/zexot-com/commitlint/rule"
+)
+
+const zero = "0000000000000000000000000000000000000000"
+
+func TestParseReceive(t *testing.T) {
+	input := "aaa bbb refs/heads/main\n\n" + zero + " ccc refs/heads/new\nddd " + zero + " refs/heads/old\n"
+
+	updates, err := ParseReceive(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != 3 {
+		t.Fatalf("got %d updates, want 3", len(updates))
+	}
+	if updates[0] != (Update{Ref: "refs/heads/main", Old: "aaa", New: "bbb"}) {
+		t.Errorf("unexpected update %+v", updates[0])
+	}
+	if !updates[1].IsCreate() || updates[1].IsDelete() {
+		t.Errorf("expected create for %+v", updates[1])
+	}
+	if !updates[2].IsDelete() {
+		t.Errorf("expected delete for %+v", updates[2])
+	}
+
+	_, err = ParseReceive(strings.NewReader("aaa bbb\n"))
+	if err == nil {
+		t.Error("expected error for invalid line")
+	}
+}
+
+func TestAction(t *testing.T) {
+	conf := Config{
+		Policies: []RefPolicy{
+			{Refs: []string{"refs/heads/main", "refs/heads/release/*"}, Action: ActionReject},
+			{Refs: []string{"refs/tags/*"}, Action: ActionSkip},
+			{Refs: []string{"refs/heads/*-wip"}, Action: ActionSkip},
+		},
+		Default: ActionWarn,
+	}
+
+	tests := map[string]string{
+		"refs/heads/main":          ActionReject,
+		"refs/heads/release/1.2":   ActionReject,
+		"refs/heads/release/v2/rc": ActionReject,
+		"refs/heads/mainline":      ActionWarn,
+		"refs/tags/v1.0.0":         ActionSkip,
+		"refs/heads/feature/x-wip": ActionSkip,
+		"refs/heads/feature/x":     ActionWarn,
+	}
+	for ref, want := range tests {
+		if got := Action(conf, ref); got != want {
+			t.Errorf("Action(%s) = %s, want %s", ref, got, want)
+		}
+	}
+
+	if got := Action(Config{}, "refs/heads/main"); got != ActionReject {
+		t.Errorf("default action = %s, want %s", got, ActionReject)
+	}
+
+	err := CheckConfig(Config{Default: "block"})
+	if err == nil {
+		t.Error("expected error for unknown action")
+	}
+}
+
+func TestLint(t *testing.T) {
+	typeEnum := &rule.TypeEnumRule{}
+	err := typeEnum.Apply(lint.RuleSetting{Argument: []interface{}{"feat", "fix"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	linter, err := lint.New(&lint.Config{Severity: lint.SeverityConfig{Default: lint.SeverityError}}, []lint.Rule{typeEnum})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var logArgs []string
+	log := func(args ...string) ([]*git.Commit, error) {
+		logArgs = args
+		return []*git.Commit{
+			{Hash: "1111111aaaa", Message: "feat: add login"},
+			{Hash: "2222222bbbb", Message: "docs: update readme\n\nbody"},
+		}, nil
+	}
+
+	commits, err := NewCommits(Update{Ref: "refs/heads/main", Old: zero, New: "bbb"}, log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(logArgs, " ") != "bbb --not --all" {
+		t.Errorf("unexpected log args %v", logArgs)
+	}
+
+	res, err := Lint(linter, "refs/heads/main", ActionReject, commits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.IsRejected() || res.Total != 2 || len(res.Failed) != 1 {
+		t.Fatalf("unexpected result %+v", res)
+	}
+
+	out := Format([]*RefResult{res})
+	for _, want := range []string{"✖ refs/heads/main: rejected, 1 of 2", "2222222 docs: update readme", "- type-enum:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output %q does not contain %q", out, want)
+		}
+	}
+
+	deleted, err := NewCommits(Update{Ref: "refs/heads/old", Old: "aaa", New: zero}, log)
+	if err != nil || len(deleted) != 0 {
+		t.Errorf("got (%v, %v) for deleted ref, want no commits", deleted, err)
+	}
+}
+
+func TestOutgoingCommits(t *testing.T) {
+	input := "refs/heads/main bbb refs/heads/main aaa\n" +
+		"refs/heads/new ccc refs/heads/new " + zero + "\n" +
+		"(delete) " + zero + " refs/heads/old ddd\n"
+
+	updates, err := ParsePush(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != 3 {
+		t.Fatalf("got %d updates, want 3", len(updates))
+	}
+
+	var logArgs []string
+	log := func(args ...string) ([]*git.Commit, error) {
+		logArgs = args
+		return nil, nil
+	}
+
+	tests := []struct {
+		remote string
+		update Update
+		args   string
+	}{
+		{"origin", updates[0], "--ignore-missing bbb --not --remotes=origin aaa"},
+		{"origin", updates[1], "--ignore-missing ccc --not --remotes=origin"},
+		{"", updates[1], "--ignore-missing ccc --not --remotes"},
+		{"origin", updates[2], ""},
+	}
+	for _, tc := range tests {
+		logArgs = nil
+		_, err := OutgoingCommits(tc.update, tc.remote, log)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Join(logArgs, " "); got != tc.args {
+			t.Errorf("OutgoingCommits(%s) log args = %q, want %q", tc.update.Ref, got, tc.args)
+		}
+	}
+
+	_, err = ParsePush(strings.NewReader("refs/heads/main bbb refs/heads/main\n"))
+	if err == nil {
+		t.Error("expected error for invalid line")
+	}
+}
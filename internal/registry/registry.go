@@ -3,6 +3,7 @@ package registry
 
 import (
 	"fmt"
+	"reflect"
 	"sync"
 
 	"github.com/zexot-com/commitlint/formatter"
@@ -24,7 +25,7 @@ func RegisterFormatter(format lint.Formatter) error {
 	return globalRegistry.RegisterFormatter(format)
 }
 
-// GetRule returns Rule with given name
+// GetRule returns a copy of Rule with given name
 func GetRule(name string) (lint.Rule, bool) {
 	return globalRegistry.GetRule(name)
 }
@@ -39,6 +40,11 @@ func Rules() []lint.Rule {
 	return globalRegistry.Rules()
 }
 
+// Formatters returns all registered formatters
+func Formatters() []lint.Formatter {
+	return globalRegistry.Formatters()
+}
+
 type registry struct {
 	mut *sync.Mutex
 
@@ -60,6 +66,17 @@ func newRegistry() *registry {
 		&rule.TypeMinLenRule{}, &rule.ScopeMinLenRule{}, &rule.DescriptionMinLenRule{},
 
 		&rule.FooterTypeEnumRule{},
+
+		&rule.ReferencesRequiredRule{}, &rule.SignedOffByRule{},
+
+		&rule.TrailerDuplicateRule{}, &rule.TrailerOrderRule{},
+		&rule.TrailerTokenCaseRule{}, &rule.TrailerValuePatternRule{},
+
+		&rule.NoSecretsRule{},
+
+		&rule.DescriptionImperativeRule{}, &rule.SpellingRule{},
+
+		&rule.GitmojiEnumRule{},
 	}
 
 	defaultFormatters := []lint.Formatter{
@@ -128,7 +145,23 @@ func (reg *registry) GetRule(name string) (lint.Rule, bool) {
 	defer reg.mut.Unlock()
 
 	cRule, ok := reg.allRules[name]
-	return cRule, ok
+	if !ok {
+		return nil, false
+	}
+	return copyRule(cRule), true
+}
+
+// copyRule returns a copy of registered rule, so that Apply of rules
+// enabled by different configs does not change the registered rule
+func copyRule(r lint.Rule) lint.Rule {
+	v := reflect.ValueOf(r)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return r
+	}
+
+	c := reflect.New(v.Elem().Type())
+	c.Elem().Set(v.Elem())
+	return c.Interface().(lint.Rule)
 }
 
 func (reg *registry) GetFormatter(name string) (lint.Formatter, bool) {
@@ -0,0 +1,312 @@
+// Package release calculates the next semantic version from conventional commits
+package release
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/zexot-com/commitlint/internal/git"
+	"github.com/zexot-com/commitlint/lint"
+)
+
+// Pre major bump modes
+const (
+	// PreMajorShift bumps minor for breaking change and patch for minor
+	// changes while major version is 0
+	PreMajorShift = "shift"
+
+	// PreMajorNormal bumps same as 1.0.0 and above, breaking change releases 1.0.0
+	PreMajorNormal = "normal"
+)
+
+const defaultInitialVersion = "v0.1.0"
+
+var (
+	defaultMinorTypes = []string{"feat"}
+	defaultPatchTypes = []string{"fix", "perf"}
+)
+
+// Config represent the version bump rules, breaking change is always major
+type Config struct {
+	// Major, Minor and Patch are the commit types which bump the version part
+	// defaults to feat for minor and fix, perf for patch
+	Major []string `yaml:"major,omitempty"`
+	Minor []string `yaml:"minor,omitempty"`
+	Patch []string `yaml:"patch,omitempty"`
+
+	// PreMajor is how versions below 1.0.0 are bumped
+	//   - shift (default): breaking change bumps minor, minor change bumps patch
+	//   - normal: same as 1.0.0 and above
+	PreMajor string `yaml:"pre-major,omitempty"`
+
+	// InitialVersion is the first version when there are no version tags
+	// defaults to v0.1.0
+	InitialVersion string `yaml:"initial-version,omitempty"`
+}
+
+// Bump represent the part of version to increment
+type Bump int
+
+// Bump Constants
+const (
+	BumpNone Bump = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+)
+
+func (b Bump) String() string {
+	switch b {
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpMajor:
+		return "major"
+	default:
+		return "none"
+	}
+}
+
+// CommitsSince returns the commits after given tag, all commits if tag is empty
+type CommitsSince func(tag string) ([]*git.Commit, error)
+
+// Calculator calculates the next version using bump rules in config
+type Calculator struct {
+	linter *lint.Linter
+	conf   Config
+
+	// bumps is commit type to bump
+	bumps map[string]Bump
+}
+
+// CheckConfig checks if the release config is valid
+func CheckConfig(conf Config) error {
+	if conf.PreMajor != "" && conf.PreMajor != PreMajorShift && conf.PreMajor != PreMajorNormal {
+		return fmt.Errorf("unknown release pre-major mode '%s', should be one of [%s %s]", conf.PreMajor, PreMajorShift, PreMajorNormal)
+	}
+	if conf.InitialVersion != "" && !semver.IsValid(conf.InitialVersion) {
+		return fmt.Errorf("invalid release initial-version '%s', should be in semver format like v0.1.0", conf.InitialVersion)
+	}
+	return nil
+}
+
+// New returns a Calculator, commits are parsed and ignored same as the linter
+func New(linter *lint.Linter, conf Config) (*Calculator, error) {
+	err := CheckConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if conf.PreMajor == "" {
+		conf.PreMajor = PreMajorShift
+	}
+	if conf.InitialVersion == "" {
+		conf.InitialVersion = defaultInitialVersion
+	}
+
+	if conf.Minor == nil {
+		conf.Minor = defaultMinorTypes
+	}
+	if conf.Patch == nil {
+		conf.Patch = defaultPatchTypes
+	}
+
+	bumps := make(map[string]Bump)
+	for _, typ := range conf.Patch {
+		bumps[typ] = BumpPatch
+	}
+	for _, typ := range conf.Minor {
+		bumps[typ] = BumpMinor
+	}
+	for _, typ := range conf.Major {
+		bumps[typ] = BumpMajor
+	}
+
+	c := &Calculator{
+		linter: linter,
+		conf:   conf,
+		bumps:  bumps,
+	}
+	return c, nil
+}
+
+// Bump returns the highest bump needed by given commits
+// ignored commits and commits which can not be parsed are skipped
+func (c *Calculator) Bump(commits []*git.Commit) Bump {
+	bump := BumpNone
+	for _, commit := range commits {
+		if c.linter.IsIgnored(commit.Message) {
+			continue
+		}
+
+		msg, err := c.linter.Parse(commit.Message)
+		if err != nil {
+			continue
+		}
+
+		if msg.IsBreakingChange() {
+			return BumpMajor
+		}
+		bump = max(bump, c.bumps[c.linter.CommitType(msg)])
+	}
+	return bump
+}
+
+// Next returns the next version for given tags and commits, prerelease
+// is the prerelease identifier like 'rc', empty for a final release.
+// returns false if commits since latest tag does not need a release
+func (c *Calculator) Next(tags []string, commitsSince CommitsSince, prerelease string) (string, bool, error) {
+	if prerelease != "" && !semver.IsValid("v1.0.0-"+prerelease+".1") {
+		return "", false, fmt.Errorf("invalid prerelease identifier '%s'", prerelease)
+	}
+
+	stable, latestPre := latestTags(tags)
+
+	commits, err := commitsSince(stable)
+	if err != nil {
+		return "", false, err
+	}
+
+	bump := c.Bump(commits)
+	if bump == BumpNone {
+		return "", false, nil
+	}
+
+	next := c.conf.InitialVersion
+	if stable != "" {
+		next = c.bumpVersion(canonical(stable), bump)
+	}
+
+	if latestPre != "" {
+		// commits after the latest prerelease decides if a new release is needed
+		sincePre, err := commitsSince(latestPre)
+		if err != nil {
+			return "", false, err
+		}
+		if prerelease != "" && c.Bump(sincePre) == BumpNone {
+			return "", false, nil
+		}
+
+		// version of prerelease is not lowered, like breaking change removed by revert
+		preBase := baseVersion(canonical(latestPre))
+		if semver.Compare(preBase, next) > 0 {
+			next = preBase
+		}
+	}
+
+	if prerelease != "" {
+		next += "-" + prerelease + "." + strconv.Itoa(nextPrereleaseNum(latestPre, next, prerelease))
+	}
+
+	// keep the tag style of existing tags, with or without 'v'
+	latest := stable
+	if latest == "" {
+		latest = latestPre
+	}
+	if latest != "" && !strings.HasPrefix(latest, "v") {
+		next = strings.TrimPrefix(next, "v")
+	}
+	return next, true, nil
+}
+
+// bumpVersion returns the version with given bump applied, version should be canonical
+func (c *Calculator) bumpVersion(version string, bump Bump) string {
+	major, minor, patch := versionParts(version)
+
+	if major == 0 && c.conf.PreMajor == PreMajorShift {
+		// breaking changes are expected before 1.0.0
+		bump--
+		if bump == BumpNone {
+			bump = BumpPatch
+		}
+	}
+
+	switch bump {
+	case BumpMajor:
+		return fmt.Sprintf("v%d.0.0", major+1)
+	case BumpMinor:
+		return fmt.Sprintf("v%d.%d.0", major, minor+1)
+	default:
+		return fmt.Sprintf("v%d.%d.%d", major, minor, patch+1)
+	}
+}
+
+// latestTags returns the highest stable version tag and the highest
+// prerelease tag above it, invalid semver tags are skipped
+func latestTags(tags []string) (stable, prerelease string) {
+	var versions []string
+	for _, t := range tags {
+		if semver.IsValid(canonical(t)) {
+			versions = append(versions, t)
+		}
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return semver.Compare(canonical(versions[i]), canonical(versions[j])) < 0
+	})
+
+	for i := len(versions) - 1; i >= 0; i-- {
+		v := canonical(versions[i])
+		if semver.Prerelease(v) == "" {
+			return versions[i], prerelease
+		}
+		if prerelease == "" {
+			prerelease = versions[i]
+		}
+	}
+	return "", prerelease
+}
+
+// nextPrereleaseNum returns the number of next prerelease of version with id
+// like 3 if latest prerelease is v1.2.0-rc.2 and next version is v1.2.0
+func nextPrereleaseNum(latestPre, version, id string) int {
+	if latestPre == "" {
+		return 1
+	}
+
+	latest := canonical(latestPre)
+	if baseVersion(latest) != version {
+		return 1
+	}
+
+	num, ok := strings.CutPrefix(semver.Prerelease(latest), "-"+id+".")
+	if !ok {
+		return 1
+	}
+	n, err := strconv.Atoi(num)
+	if err != nil {
+		return 1
+	}
+	return n + 1
+}
+
+// canonical returns the tag with 'v' prefix as needed by semver package
+func canonical(tag string) string {
+	if strings.HasPrefix(tag, "v") {
+		return tag
+	}
+	return "v" + tag
+}
+
+// baseVersion returns vMAJOR.MINOR.PATCH without prerelease and build
+func baseVersion(version string) string {
+	major, minor, patch := versionParts(version)
+	return fmt.Sprintf("v%d.%d.%d", major, minor, patch)
+}
+
+func versionParts(version string) (major, minor, patch int) {
+	parts := strings.SplitN(strings.TrimPrefix(semver.Canonical(version), "v"), ".", 3)
+	major, _ = strconv.Atoi(parts[0])
+	minor, _ = strconv.Atoi(parts[1])
+
+	// patch can have prerelease and build suffix
+	patchStr, _, _ := strings.Cut(parts[2], "-")
+	patchStr, _, _ = strings.Cut(patchStr, "+")
+	patch, _ = strconv.Atoi(patchStr)
+	return major, minor, patch
+}
@@ -0,0 +1,131 @@
+package release
+
+import (
+	"testing"
+
+	"github.com/zexot-com/commitlint/internal/git"
+	"github.com/zexot-com/commitlint/lint"
+	"github.com/zexot-com/commitlint/rule"
+)
+
+func TestNext(t *testing.T) {
+	typeEnum := &rule.TypeEnumRule{Types: []string{"feat", "fix"}, Aliases: map[string]string{"feature": "feat"}}
+	linter, err := lint.New(&lint.Config{Ignores: []string{`^WIP`}}, []lint.Rule{typeEnum})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		conf       Config
+		tags       []string
+		commits    map[string][]string
+		prerelease string
+		want       string
+	}{
+		{
+			name:    "no tags",
+			commits: map[string][]string{"": {"feat: a"}},
+			want:    "v0.1.0",
+		},
+		{
+			name:    "feat is minor",
+			tags:    []string{"v1.2.3", "v1.0.0", "latest"},
+			commits: map[string][]string{"v1.2.3": {"fix: a", "feat: b"}},
+			want:    "v1.3.0",
+		},
+		{
+			name:    "breaking is major",
+			tags:    []string{"v1.2.3"},
+			commits: map[string][]string{"v1.2.3": {"fix!: a"}},
+			want:    "v2.0.0",
+		},
+		{
+			name:    "pre major shift",
+			tags:    []string{"v0.4.1"},
+			commits: map[string][]string{"v0.4.1": {"feat!: a"}},
+			want:    "v0.5.0",
+		},
+		{
+			name:    "pre major shift minor to patch",
+			tags:    []string{"0.4.1"},
+			commits: map[string][]string{"0.4.1": {"feat: a"}},
+			want:    "0.4.2",
+		},
+		{
+			name:    "pre major normal",
+			conf:    Config{PreMajor: PreMajorNormal},
+			tags:    []string{"v0.4.1"},
+			commits: map[string][]string{"v0.4.1": {"feat!: a"}},
+			want:    "v1.0.0",
+		},
+		{
+			name:    "no release",
+			tags:    []string{"v1.0.0"},
+			commits: map[string][]string{"v1.0.0": {"docs: a", "WIP: feat: b", "not conventional"}},
+		},
+		{
+			name:    "alias bumps as its type",
+			tags:    []string{"v1.0.0"},
+			commits: map[string][]string{"v1.0.0": {"fix: a", "feature: b"}},
+			want:    "v1.1.0",
+		},
+		{
+			name:    "custom bump types",
+			conf:    Config{Patch: []string{"docs"}},
+			tags:    []string{"v1.0.0"},
+			commits: map[string][]string{"v1.0.0": {"docs: a", "fix: b"}},
+			want:    "v1.0.1",
+		},
+		{
+			name:       "first prerelease",
+			tags:       []string{"v1.0.0"},
+			commits:    map[string][]string{"v1.0.0": {"feat: a"}},
+			prerelease: "rc",
+			want:       "v1.1.0-rc.1",
+		},
+		{
+			name:       "next prerelease",
+			tags:       []string{"v1.0.0", "v1.1.0-rc.1"},
+			commits:    map[string][]string{"v1.0.0": {"feat: a", "fix: b"}, "v1.1.0-rc.1": {"fix: b"}},
+			prerelease: "rc",
+			want:       "v1.1.0-rc.2",
+		},
+		{
+			name:       "prerelease without new commits",
+			tags:       []string{"v1.0.0", "v1.1.0-rc.1"},
+			commits:    map[string][]string{"v1.0.0": {"feat: a"}},
+			prerelease: "rc",
+		},
+		{
+			name:    "promote prerelease",
+			tags:    []string{"v1.0.0", "v1.1.0-rc.2"},
+			commits: map[string][]string{"v1.0.0": {"feat: a"}},
+			want:    "v1.1.0",
+		},
+	}
+
+	for _, test := range tests {
+		calc, err := New(linter, test.conf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitsSince := func(tag string) ([]*git.Commit, error) {
+			var commits []*git.Commit
+			for _, msg := range test.commits[tag] {
+				commits = append(commits, &git.Commit{Message: msg})
+			}
+			return commits, nil
+		}
+
+		got, isRelease, err := calc.Next(test.tags, commitsSince, test.prerelease)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+			continue
+		}
+		if isRelease != (test.want != "") || got != test.want {
+			t.Errorf("%s: expected %q, got %q (release %v)", test.name, test.want, got, isRelease)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+// Package report aggregates lint results of git history into statistics
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/zexot-com/commitlint/internal/git"
+	"github.com/zexot-com/commitlint/lint"
+)
+
+const monthFormat = "2006-01"
+
+// Count represent number of occurrences of a name, like a rule or type
+type Count struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Group represent lint results of commits grouped by author or month
+type Group struct {
+	Name     string  `json:"name"`
+	Total    int     `json:"total"`
+	Passed   int     `json:"passed"`
+	PassRate float64 `json:"pass_rate"`
+}
+
+// Report represent lint statistics of commits
+type Report struct {
+	Total    int     `json:"total"`
+	Passed   int     `json:"passed"`
+	Failed   int     `json:"failed"`
+	Ignored  int     `json:"ignored"`
+	PassRate float64 `json:"pass_rate"`
+
+	// Rules are the violated rules, most violated first
+	Rules []Count `json:"rules"`
+
+	Types  []Count `json:"types"`
+	Scopes []Count `json:"scopes"`
+
+	Authors []*Group `json:"authors"`
+	Months  []*Group `json:"months"`
+}
+
+// Generate lints the commits and returns the statistics, a commit passes
+// if it has no issues with error severity, same as the commit-msg hook
+func Generate(linter *lint.Linter, commits []*git.Commit) (*Report, error) {
+	r := &Report{}
+
+	rules := make(map[string]int)
+	types := make(map[string]int)
+	scopes := make(map[string]int)
+	authors := make(map[string]*Group)
+	months := make(map[string]*Group)
+
+	for _, commit := range commits {
+		if linter.IsIgnored(commit.Message) {
+			r.Ignored++
+			continue
+		}
+
+		author := lint.Signature{Name: commit.AuthorName, Email: commit.AuthorEmail}
+		msg, result, err := linter.ParseAndLintCommit(commit.Message, author)
+		if err != nil {
+			return nil, err
+		}
+
+		isPassed := true
+		for _, issue := range result.Issues() {
+			rules[issue.RuleName()]++
+			if issue.Severity() == lint.SeverityError {
+				isPassed = false
+			}
+		}
+
+		if msg != nil {
+			types[linter.CommitType(msg)]++
+			if msg.Scope() != "" {
+				scopes[msg.Scope()]++
+			}
+		}
+
+		authorName := fmt.Sprintf("%s <%s>", commit.AuthorName, commit.AuthorEmail)
+		addToGroup(authors, authorName, isPassed)
+		addToGroup(months, commit.AuthorDate.Format(monthFormat), isPassed)
+
+		r.Total++
+		if isPassed {
+			r.Passed++
+		}
+	}
+
+	r.Failed = r.Total - r.Passed
+	r.PassRate = passRate(r.Passed, r.Total)
+
+	r.Rules = sortedCounts(rules)
+	r.Types = sortedCounts(types)
+	r.Scopes = sortedCounts(scopes)
+
+	r.Authors = sortedGroups(authors, func(a, b *Group) bool {
+		if a.Total != b.Total {
+			return a.Total > b.Total
+		}
+		return a.Name < b.Name
+	})
+	r.Months = sortedGroups(months, func(a, b *Group) bool {
+		return a.Name < b.Name
+	})
+	return r, nil
+}
+
+func addToGroup(groups map[string]*Group, name string, isPassed bool) {
+	g, ok := groups[name]
+	if !ok {
+		g = &Group{Name: name}
+		groups[name] = g
+	}
+	g.Total++
+	if isPassed {
+		g.Passed++
+	}
+	g.PassRate = passRate(g.Passed, g.Total)
+}
+
+// passRate returns passed percentage rounded to 2 decimals
+func passRate(passed, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	rate := float64(passed) * 100 / float64(total)
+	return float64(int(rate*100+0.5)) / 100
+}
+
+// sortedCounts returns counts with highest count first
+func sortedCounts(counts map[string]int) []Count {
+	out := make([]Count, 0, len(counts))
+	for name, count := range counts {
+		out = append(out, Count{Name: name, Count: count})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+func sortedGroups(groups map[string]*Group, less func(a, b *Group) bool) []*Group {
+	out := make([]*Group, 0, len(groups))
+	for _, g := range groups {
+		out = append(out, g)
+	}
+	sort.Slice(out, func(i, j int) bool { return less(out[i], out[j]) })
+	return out
+}
+
+// Table returns the report as plain text tables
+func (r *Report) Table() string {
+	w := &strings.Builder{}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Commits\t%d\n", r.Total)
+	fmt.Fprintf(tw, "Passed\t%d\n", r.Passed)
+	fmt.Fprintf(tw, "Failed\t%d\n", r.Failed)
+	fmt.Fprintf(tw, "Ignored\t%d\n", r.Ignored)
+	fmt.Fprintf(tw, "Pass Rate\t%.2f%%\n", r.PassRate)
+
+	writeCounts(tw, "Violated Rules", "RULE", r.Rules)
+	writeCounts(tw, "Types", "TYPE", r.Types)
+	writeCounts(tw, "Scopes", "SCOPE", r.Scopes)
+	writeGroups(tw, "Authors", "AUTHOR", r.Authors)
+	writeGroups(tw, "Months", "MONTH", r.Months)
+
+	_ = tw.Flush()
+	return w.String()
+}
+
+func writeCounts(tw *tabwriter.Writer, title, nameHeader string, counts []Count) {
+	if len(counts) == 0 {
+		return
+	}
+	fmt.Fprintf(tw, "\n%s\n%s\tCOUNT\n", title, nameHeader)
+	for _, c := range counts {
+		fmt.Fprintf(tw, "%s\t%d\n", displayName(c.Name), c.Count)
+	}
+}
+
+func writeGroups(tw *tabwriter.Writer, title, nameHeader string, groups []*Group) {
+	if len(groups) == 0 {
+		return
+	}
+	fmt.Fprintf(tw, "\n%s\n%s\tCOMMITS\tPASSED\tPASS RATE\n", title, nameHeader)
+	for _, g := range groups {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.2f%%\n", g.Name, g.Total, g.Passed, g.PassRate)
+	}
+}
+
+// displayName shows empty type of unparsable header
+func displayName(name string) string {
+	if name == "" {
+		return "(none)"
+	}
+	return name
+}
+
+// CSV returns the report as csv with columns section, name, count, passed
+// and pass_rate. count is the number of commits in the row
+func (r *Report) CSV() (string, error) {
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+
+	rows := [][]string{
+		{"section", "name", "count", "passed", "pass_rate"},
+		{"summary", "total", strconv.Itoa(r.Total), strconv.Itoa(r.Passed), formatRate(r.PassRate)},
+		{"summary", "ignored", strconv.Itoa(r.Ignored), "", ""},
+	}
+
+	countRows := func(section string, counts []Count) {
+		for _, c := range counts {
+			rows = append(rows, []string{section, c.Name, strconv.Itoa(c.Count), "", ""})
+		}
+	}
+	groupRows := func(section string, groups []*Group) {
+		for _, g := range groups {
+			rows = append(rows, []string{section, g.Name, strconv.Itoa(g.Total), strconv.Itoa(g.Passed), formatRate(g.PassRate)})
+		}
+	}
+
+	countRows("rule", r.Rules)
+	countRows("type", r.Types)
+	countRows("scope", r.Scopes)
+	groupRows("author", r.Authors)
+	groupRows("month", r.Months)
+
+	err := w.WriteAll(rows)
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func formatRate(rate float64) string {
+	return strconv.FormatFloat(rate, 'f', 2, 64)
+}
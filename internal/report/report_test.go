@@ -0,0 +1,74 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zexot-com/commitlint/internal/git"
+	"github.com/zexot-com/commitlint/lint"
+	"github.com/zexot-com/commitlint/rule"
+)
+
+func TestGenerate(t *testing.T) {
+	typeEnum := &rule.TypeEnumRule{}
+	err := typeEnum.Apply(lint.RuleSetting{
+		Argument: []interface{}{"feat", "fix"},
+		Flags: map[string]interface{}{
+			"aliases":        map[interface{}]interface{}{"feature": "feat"},
+			"accept-aliases": true,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conf := &lint.Config{
+		Severity: lint.SeverityConfig{Default: lint.SeverityError},
+		Ignores:  []string{`^Merge`},
+	}
+	linter, err := lint.New(conf, []lint.Rule{typeEnum})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jan := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+	commits := []*git.Commit{
+		{AuthorName: "A", AuthorEmail: "a@x.io", AuthorDate: jan, Message: "feat(api): add login"},
+		{AuthorName: "A", AuthorEmail: "a@x.io", AuthorDate: feb, Message: "docs: readme"},
+		{AuthorName: "B", AuthorEmail: "b@x.io", AuthorDate: feb, Message: "fix(api): nil check"},
+		{AuthorName: "B", AuthorEmail: "b@x.io", AuthorDate: feb, Message: "Merge branch 'main'"},
+		{AuthorName: "B", AuthorEmail: "b@x.io", AuthorDate: feb, Message: "feature: add logout"},
+	}
+
+	r, err := Generate(linter, commits)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Total != 4 || r.Passed != 3 || r.Failed != 1 || r.Ignored != 1 || r.PassRate != 75 {
+		t.Errorf("unexpected summary %+v", r)
+	}
+	if len(r.Rules) != 1 || r.Rules[0] != (Count{Name: "type-enum", Count: 1}) {
+		t.Errorf("unexpected rules %v", r.Rules)
+	}
+	// alias is counted as its type
+	if len(r.Types) != 3 || r.Types[0] != (Count{Name: "feat", Count: 2}) {
+		t.Errorf("unexpected types %v", r.Types)
+	}
+	if len(r.Scopes) != 1 || r.Scopes[0] != (Count{Name: "api", Count: 2}) {
+		t.Errorf("unexpected scopes %v", r.Scopes)
+	}
+	if len(r.Months) != 2 || r.Months[0].Name != "2026-01" || r.Months[1].PassRate != 66.67 {
+		t.Errorf("unexpected months %+v %+v", r.Months[0], r.Months[1])
+	}
+
+	out, err := r.CSV()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "author,A <a@x.io>,2,1,50.00\n") {
+		t.Errorf("author row missing in csv\n%s", out)
+	}
+}
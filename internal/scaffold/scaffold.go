@@ -0,0 +1,142 @@
+// Package scaffold generates commit message templates from lint config
+package scaffold
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/zexot-com/commitlint/lint"
+	"github.com/zexot-com/commitlint/rule"
+)
+
+// headerHint is the first hint line, used to detect already added hints
+const headerHint = "<type>(<scope>): <description>"
+
+// defaultRefPattern matches jira like keys in branch name
+// when references-required rule is not enabled
+var defaultRefPattern = regexp.MustCompile(`[A-Z][A-Z0-9]+-[0-9]+`)
+
+// commit message sources passed to prepare-commit-msg hook
+// for which the message is already written
+var skipSources = []string{"message", "merge", "squash", "commit"}
+
+// FooterHint represent the footer token required for commit types
+type FooterHint struct {
+	Token  string
+	Types  []string
+	Values []string
+}
+
+// Scaffold represent the hints for writing a commit message
+type Scaffold struct {
+	Types   []string
+	Scopes  []string
+	Footers []FooterHint
+
+	// RefToken is footer token used for the reference in branch name
+	RefToken string
+
+	// CommentChar is used to write hints, git strips the comment lines
+	CommentChar string
+
+	branchRef func(branch string) string
+}
+
+// New returns Scaffold with hints from the enabled rules
+func New(rules []lint.Rule) *Scaffold {
+	s := &Scaffold{
+		RefToken:    "Refs",
+		CommentChar: "#",
+		branchRef:   defaultRefPattern.FindString,
+	}
+
+	for _, r := range rules {
+		switch r := r.(type) {
+		case *rule.TypeEnumRule:
+			s.Types = r.Types
+		case *rule.ScopeEnumRule:
+			s.Scopes = r.Scopes
+		case *rule.FooterTypeEnumRule:
+			for _, p := range r.Params {
+				s.Footers = append(s.Footers, FooterHint{Token: p.Token, Types: p.Types, Values: p.Values})
+			}
+		case *rule.ReferencesRequiredRule:
+			s.RefToken = r.FooterToken()
+			s.branchRef = r.BranchReference
+		}
+	}
+	return s
+}
+
+// Template returns commit template with hints as comments
+// first line is left empty for the header
+func (s *Scaffold) Template() string {
+	return "\n\n" + s.comments()
+}
+
+// Prepare returns the message of prepare-commit-msg hook pre filled with
+// hints and the reference in branch name. Message is unchanged if it is
+// already written, like for 'git commit -m' or merge commits
+func (s *Scaffold) Prepare(msg, source, branch string) string {
+	for _, skip := range skipSources {
+		if source == skip {
+			return msg
+		}
+	}
+
+	if s.hasContent(msg) || strings.Contains(msg, headerHint) {
+		return msg
+	}
+
+	w := &strings.Builder{}
+	w.WriteString("\n")
+	if ref := s.branchRef(branch); ref != "" {
+		w.WriteString("\n" + s.RefToken + ": " + ref + "\n")
+	}
+	w.WriteString("\n" + s.comments())
+	if msg != "" {
+		w.WriteString("\n" + strings.TrimLeft(msg, "\n"))
+	}
+	return w.String()
+}
+
+func (s *Scaffold) comments() string {
+	lines := []string{headerHint, ""}
+
+	if len(s.Types) > 0 {
+		lines = append(lines, "types: "+strings.Join(s.Types, ", "))
+	}
+	if len(s.Scopes) > 0 {
+		lines = append(lines, "scopes: "+strings.Join(s.Scopes, ", "))
+	}
+
+	if len(s.Footers) > 0 {
+		lines = append(lines, "", "required footers:")
+		for _, f := range s.Footers {
+			lines = append(lines, "  "+f.Token+": <"+strings.Join(f.Values, "|")+"...> for "+strings.Join(f.Types, ", "))
+		}
+	}
+
+	lines = append(lines, "", "BREAKING CHANGE: <description> for breaking changes")
+
+	w := &strings.Builder{}
+	for _, line := range lines {
+		if line == "" {
+			w.WriteString(s.CommentChar + "\n")
+			continue
+		}
+		w.WriteString(s.CommentChar + " " + line + "\n")
+	}
+	return w.String()
+}
+
+// hasContent checks if msg has any non comment text
+func (s *Scaffold) hasContent(msg string) bool {
+	for _, line := range strings.Split(msg, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, s.CommentChar) {
+			return true
+		}
+	}
+	return false
+}
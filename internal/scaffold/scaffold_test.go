@@ -0,0 +1,53 @@
+package scaffold
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zexot-com/commitlint/lint"
+	"github.com/zexot-com/commitlint/rule"
+)
+
+func TestPrepare(t *testing.T) {
+	typeEnum := &rule.TypeEnumRule{}
+	err := typeEnum.Apply(lint.RuleSetting{Argument: []interface{}{"feat", "fix"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := New([]lint.Rule{typeEnum})
+
+	gitComments := "\n# Please enter the commit message for your changes.\n"
+
+	tests := []struct {
+		name     string
+		msg      string
+		source   string
+		branch   string
+		contains []string
+		same     bool
+	}{
+		{"empty message", gitComments, "", "main", []string{"# types: feat, fix", gitComments[1:]}, false},
+		{"branch reference", gitComments, "", "feature/PAY-12-login", []string{"\n\nRefs: PAY-12\n"}, false},
+		{"message source", "feat: add login\n", "message", "PAY-12", nil, true},
+		{"merge source", "Merge branch 'x'\n", "merge", "main", nil, true},
+		{"written template", "feat: \n", "template", "main", nil, true},
+		{"hints already added", s.Template(), "template", "main", nil, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := s.Prepare(tc.msg, tc.source, tc.branch)
+			if tc.same && got != tc.msg {
+				t.Errorf("message changed to %q", got)
+			}
+			if !strings.HasPrefix(got, "\n") && !tc.same {
+				t.Errorf("header line is not empty in %q", got)
+			}
+			for _, c := range tc.contains {
+				if !strings.Contains(got, c) {
+					t.Errorf("%q does not contain %q", got, c)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package lint
+
+import "strings"
+
+var (
+	_ TrailerCommit = (*authoredCommit)(nil)
+	_ EmojiCommit   = (*authoredCommit)(nil)
+	_ TrailerCommit = (*simpleCommit)(nil)
+)
+
+type authoredCommit struct {
+	Commit
+	author Signature
+}
+
+// WithAuthor returns the given commit with author information
+func WithAuthor(msg Commit, author Signature) AuthoredCommit {
+	return &authoredCommit{
+		Commit: msg,
+		author: author,
+	}
+}
+
+func (a *authoredCommit) Author() Signature { return a.author }
+
+func (a *authoredCommit) Trailers() []Trailer { return CommitTrailers(a.Commit) }
+
+func (a *authoredCommit) Emoji() string { return CommitEmoji(a.Commit) }
+
+// simpleCommit is a commit with header fields set by the parser, body and
+// footer are parsed same as git trailers, last paragraph with trailers is the footer
+type simpleCommit struct {
+	message     string
+	header      string
+	body        string
+	footer      string
+	typ         string
+	scope       string
+	description string
+	breaking    bool
+	trailers    []Trailer
+}
+
+// newSimpleCommit returns a commit with header, body, footer and trailers
+// parsed from msg, type, scope and description are empty
+func newSimpleCommit(msg string) *simpleCommit {
+	c := &simpleCommit{
+		message: msg,
+	}
+
+	msg = strings.TrimRight(msg, "\n\t ")
+	lines := strings.Split(msg, "\n")
+	c.header = lines[0]
+
+	c.trailers = ParseTrailers(msg)
+	if len(c.trailers) == 0 {
+		c.body = strings.Trim(strings.Join(lines[1:], "\n"), "\n")
+		return c
+	}
+
+	// footer starts at first trailer, line numbers are counted from header
+	footerStart := c.trailers[0].Line - 1
+	c.footer = strings.Join(lines[footerStart:], "\n")
+	c.body = strings.Trim(strings.Join(lines[1:footerStart], "\n"), "\n")
+
+	for _, t := range c.trailers {
+		if t.IsBreakingChange() {
+			c.breaking = true
+		}
+	}
+	return c
+}
+
+func (c *simpleCommit) Message() string        { return c.message }
+func (c *simpleCommit) Header() string         { return c.header }
+func (c *simpleCommit) Body() string           { return c.body }
+func (c *simpleCommit) Footer() string         { return c.footer }
+func (c *simpleCommit) Type() string           { return c.typ }
+func (c *simpleCommit) Scope() string          { return c.scope }
+func (c *simpleCommit) Description() string    { return c.description }
+func (c *simpleCommit) IsBreakingChange() bool { return c.breaking }
+func (c *simpleCommit) Trailers() []Trailer    { return c.trailers }
+
+func (c *simpleCommit) Notes() []Note {
+	notes := make([]Note, len(c.trailers))
+	for i, t := range c.trailers {
+		notes[i] = &trailerNote{token: t.Token, value: t.Value}
+	}
+	return notes
+}
+
+// trailerNote represent a trailer as footer note
+type trailerNote struct {
+	token string
+	value string
+}
+
+func (n *trailerNote) Token() string { return n.token }
+func (n *trailerNote) Value() string { return n.value }
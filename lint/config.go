@@ -4,6 +4,10 @@ package lint
 type RuleSetting struct {
 	Argument interface{}            `yaml:"argument"`
 	Flags    map[string]interface{} `yaml:"flags,omitempty"`
+
+	// Dir is the directory of config file, relative paths in
+	// argument and flags are resolved against it
+	Dir string `yaml:"-"`
 }
 
 // SeverityConfig represent severity levels for rules
@@ -12,6 +16,31 @@ type SeverityConfig struct {
 	Rules   map[string]Severity `yaml:"rules,omitempty"`
 }
 
+// Parser Name Constants
+const (
+	// ParserConventional parses conventional commits header 'type(scope)!: description'
+	ParserConventional = "conventional"
+
+	// ParserRegex parses header with named groups in HeaderPattern
+	ParserRegex = "regex"
+)
+
+// ParserConfig represent config for commit message parser
+type ParserConfig struct {
+	// Name of the built-in parser, defaults to conventional
+	// if HeaderPattern is given, defaults to regex
+	Name string `yaml:"name,omitempty"`
+
+	// HeaderPattern is the header grammar for regex parser with named
+	// groups type, scope, description and breaking
+	// like '^\[(?P<type>[A-Z]+)\] (?P<scope>\w+): (?P<description>.+)$'
+	HeaderPattern string `yaml:"header-pattern,omitempty"`
+
+	// Emoji enables parsing of gitmoji shortcode or unicode emoji
+	// before the header, like ':sparkles: feat: add login'
+	Emoji bool `yaml:"emoji,omitempty"`
+}
+
 // Config represent linter config
 type Config struct {
 	// MinVersion is the minimum version of commitlint required
@@ -29,11 +58,24 @@ type Config struct {
 
 	// Settings is rule name to rule settings
 	Settings map[string]RuleSetting `yaml:"settings"`
+
+	// Parser config
+	Parser ParserConfig `yaml:"parser,omitempty"`
+
+	// Ignores are regex patterns of commit messages which are not linted
+	// like '^Merge branch' or '^Bump [^ ]+ from', also skipped in changelog
+	Ignores []string `yaml:"ignores,omitempty"`
+
+	// Dir is the directory of config file, empty for default config
+	// relative paths in rule settings are resolved against working directory
+	Dir string `yaml:"-"`
 }
 
 // GetRule returns RuleConfig for given rule name
 func (c *Config) GetRule(ruleName string) RuleSetting {
-	return c.Settings[ruleName]
+	setting := c.Settings[ruleName]
+	setting.Dir = c.Dir
+	return setting
 }
 
 // GetSeverity returns Severity for given ruleName
@@ -0,0 +1,137 @@
+package lint
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var _ EmojiCommit = (*emojiCommit)(nil)
+
+// shortcodeRe matches gitmoji shortcode like :sparkles:
+var shortcodeRe = regexp.MustCompile(`^:[a-z0-9_+\-]+:`)
+
+// emojiParser parses the emoji before header and
+// delegates rest of the message to wrapped parser
+type emojiParser struct {
+	p Parser
+}
+
+func newEmojiParser(p Parser) *emojiParser {
+	return &emojiParser{p: p}
+}
+
+func (e *emojiParser) Parse(input string) (Commit, error) {
+	emoji, rest := splitEmoji(input)
+	if emoji == "" {
+		return e.p.Parse(input)
+	}
+
+	c, err := e.p.Parse(rest)
+	if err != nil {
+		return nil, withEmojiPrefix(err, input[:len(input)-len(rest)])
+	}
+
+	return &emojiCommit{
+		Commit:  c,
+		emoji:   emoji,
+		message: input,
+	}, nil
+}
+
+// withEmojiPrefix adjusts the location and suggestion of parse error
+// with the emoji prefix stripped before parsing
+func withEmojiPrefix(err error, prefix string) error {
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		return err
+	}
+
+	adjusted := *parseErr
+	adjusted.Header = prefix + parseErr.Header
+	if adjusted.Column > 0 {
+		adjusted.Column += utf8.RuneCountInString(prefix)
+	}
+	if adjusted.Suggestion != "" {
+		adjusted.Suggestion = prefix + parseErr.Suggestion
+	}
+	return &adjusted
+}
+
+// emojiCommit is a commit with emoji before header
+// message and header includes the emoji
+type emojiCommit struct {
+	Commit
+	emoji   string
+	message string
+}
+
+func (e *emojiCommit) Emoji() string { return e.emoji }
+
+func (e *emojiCommit) Trailers() []Trailer { return CommitTrailers(e.Commit) }
+
+func (e *emojiCommit) Message() string { return e.message }
+
+func (e *emojiCommit) Header() string {
+	return strings.SplitN(e.message, "\n", 2)[0]
+}
+
+// CommitEmoji returns the emoji before header of msg, empty if msg
+// does not implement EmojiCommit, like when emoji parsing is not enabled
+func CommitEmoji(msg Commit) string {
+	if ec, ok := msg.(EmojiCommit); ok {
+		return ec.Emoji()
+	}
+	return ""
+}
+
+// splitEmoji returns the shortcode or unicode emoji at start of msg
+// and rest of the msg without the emoji and following spaces
+func splitEmoji(msg string) (emoji, rest string) {
+	if code := shortcodeRe.FindString(msg); code != "" {
+		return code, strings.TrimLeft(msg[len(code):], " ")
+	}
+
+	end := emojiEnd(msg)
+	if end == 0 {
+		return "", msg
+	}
+	return msg[:end], strings.TrimLeft(msg[end:], " ")
+}
+
+// emojiEnd returns the byte length of emoji sequence at start of s
+// including variation selectors, skin tone modifiers and joined emoji
+func emojiEnd(s string) int {
+	first, size := utf8.DecodeRuneInString(s)
+	if !isPictographic(first) {
+		return 0
+	}
+
+	end := size
+	for end < len(s) {
+		ch, size := utf8.DecodeRuneInString(s[end:])
+		switch {
+		case ch == 0xFE0F || (ch >= 0x1F3FB && ch <= 0x1F3FF):
+			end += size
+		case ch == 0x200D:
+			// zero width joiner joins the next emoji
+			next, nextSize := utf8.DecodeRuneInString(s[end+size:])
+			if !isPictographic(next) {
+				return end
+			}
+			end += size + nextSize
+		default:
+			return end
+		}
+	}
+	return end
+}
+
+// isPictographic checks if ch is in the common emoji blocks
+func isPictographic(ch rune) bool {
+	return (ch >= 0x1F000 && ch <= 0x1FAFF) ||
+		(ch >= 0x2300 && ch <= 0x23FF) ||
+		(ch >= 0x2600 && ch <= 0x27BF) ||
+		(ch >= 0x2B00 && ch <= 0x2BFF)
+}
@@ -0,0 +1,27 @@
+package lint
+
+import "testing"
+
+func TestSplitEmoji(t *testing.T) {
+	tests := []struct {
+		msg   string
+		emoji string
+		rest  string
+	}{
+		{msg: "feat: add login", emoji: "", rest: "feat: add login"},
+		{msg: ":sparkles: feat(api): add", emoji: ":sparkles:", rest: "feat(api): add"},
+		{msg: ":+1:  fix: x", emoji: ":+1:", rest: "fix: x"},
+		{msg: "✨ feat: add", emoji: "✨", rest: "feat: add"},
+		{msg: "⚡️ perf: x", emoji: "⚡️", rest: "perf: x"},
+		{msg: "👍🏽 fix: x", emoji: "👍🏽", rest: "fix: x"},
+		{msg: "🧑‍💻 chore: x", emoji: "🧑‍💻", rest: "chore: x"},
+		{msg: "feat: ✨ add", emoji: "", rest: "feat: ✨ add"},
+	}
+
+	for _, test := range tests {
+		emoji, rest := splitEmoji(test.msg)
+		if emoji != test.emoji || rest != test.rest {
+			t.Errorf("%q: expected %q, %q, got %q, %q", test.msg, test.emoji, test.rest, emoji, rest)
+		}
+	}
+}
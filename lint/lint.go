@@ -39,6 +39,38 @@ type Commit interface {
 	IsBreakingChange() bool
 }
 
+// TrailerCommit is an optional interface for commits which parse the git
+// trailers of footer, use CommitTrailers to get trailers of any Commit
+type TrailerCommit interface {
+	Commit
+
+	// Trailers returns the trailers in footer in the order they are written
+	Trailers() []Trailer
+}
+
+// EmojiCommit is an optional interface for commits with emoji before the
+// header, use CommitEmoji to get emoji of any Commit
+type EmojiCommit interface {
+	Commit
+
+	// Emoji returns the gitmoji shortcode or unicode emoji before the header
+	// empty if header has no emoji
+	Emoji() string
+}
+
+// Signature represent the name and email of a git identity
+type Signature struct {
+	Name  string
+	Email string
+}
+
+// AuthoredCommit represent a commit message with known author
+// like commits read from git history
+type AuthoredCommit interface {
+	Commit
+	Author() Signature
+}
+
 // Parser parses given commit message
 type Parser interface {
 	Parse(msg string) (Commit, error)
@@ -53,6 +85,26 @@ type Formatter interface {
 	Format(result *Result) (string, error)
 }
 
+// BatchResult is the lint result of one of many linted commits
+type BatchResult struct {
+	// Kind of the linted item, like commit or patch
+	Kind string
+
+	// ID identifies the item, like commit hash or patch file and index
+	ID string
+
+	Result *Result
+}
+
+// BatchFormatter is an optional interface for formatters which format the
+// results of many commits together, like commits in range, into one output
+type BatchFormatter interface {
+	Formatter
+
+	// FormatBatch formats the results in given order
+	FormatBatch(results []BatchResult) (string, error)
+}
+
 // Rule represent a linter rule
 type Rule interface {
 	// Name returns name of the rule, it should be a unique identifier
@@ -68,3 +120,62 @@ type Rule interface {
 	// if invalid, return a error messages with false
 	Validate(msg Commit) (issue *Issue, isValid bool)
 }
+
+// PartialRule is an optional interface for rules which does not need the
+// parsed header, like body and footer rules. If the header can not be parsed,
+// these rules are still run with a partially parsed commit which has raw
+// header, body, footer and trailers but empty type, scope and description
+type PartialRule interface {
+	Rule
+
+	// CanValidatePartial returns true if rule does not use type, scope,
+	// description or breaking change marker in header
+	CanValidatePartial() bool
+}
+
+// Environment represent where a new commit is being made, it is known only
+// when linting the message of a commit being made, like in commit-msg hook
+type Environment struct {
+	// Branch is the current branch, empty if unknown
+	Branch string
+
+	// Identity is the committer identity, empty if unknown
+	Identity Signature
+}
+
+// EnvironmentRule is an optional interface for rules which use the
+// environment of commit being made, like current branch or committer identity
+type EnvironmentRule interface {
+	Rule
+
+	// SetEnvironment is called with the environment given by WithEnvironment
+	// option, before Validate
+	SetEnvironment(env Environment)
+}
+
+// TypeAliasRule is an optional interface for rules which define aliases of
+// commit types, like 'feature' for 'feat'. Commits with an alias are grouped
+// with its type in changelog and next version
+type TypeAliasRule interface {
+	Rule
+
+	// AliasType returns the type of given alias, false if typ is not an alias
+	AliasType(typ string) (string, bool)
+}
+
+// Schema represent a JSON Schema, like {"type": "integer"}
+type Schema map[string]interface{}
+
+// SchemaRule is an optional interface for rules which describe their argument
+// and flags as JSON Schema, used by config schema. Rules which do not
+// implement it accept any argument and flags in the schema
+type SchemaRule interface {
+	Rule
+
+	// ArgumentSchema returns the schema of rule argument
+	ArgumentSchema() Schema
+
+	// FlagsSchema returns the schema of each flag, keyed by flag name
+	// nil if rule has no flags
+	FlagsSchema() map[string]Schema
+}
@@ -1,36 +1,192 @@
 // Package lint provides a simple linter for conventional commits
 package lint
 
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
 // Linter is linter for commit message
 type Linter struct {
 	conf  *Config
 	rules []Rule
 
-	parser Parser
+	parser  Parser
+	ignores []*regexp.Regexp
+
+	env *Environment
+}
+
+// Option configures the Linter
+type Option func(l *Linter)
+
+// WithParser sets the parser used to parse commit messages
+// it overrides the parser config
+func WithParser(p Parser) Option {
+	return func(l *Linter) {
+		l.parser = p
+	}
+}
+
+// WithEnvironment sets the environment of commit being made for rules
+// implementing EnvironmentRule, it should be set only when linting a new
+// commit message, not for commits in history or from other repositories
+func WithEnvironment(env Environment) Option {
+	return func(l *Linter) {
+		l.env = &env
+	}
 }
 
 // New returns a new Linter instance with given config and rules
-func New(conf *Config, rules []Rule) (*Linter, error) {
+func New(conf *Config, rules []Rule, opts ...Option) (*Linter, error) {
 	l := &Linter{
-		conf:   conf,
-		rules:  rules,
-		parser: newParser(),
+		conf:  conf,
+		rules: rules,
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	if l.env != nil {
+		for _, r := range rules {
+			if envRule, ok := r.(EnvironmentRule); ok {
+				envRule.SetEnvironment(*l.env)
+			}
+		}
+	}
+
+	for _, pattern := range conf.Ignores {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ignore pattern '%s': %w", pattern, err)
+		}
+		l.ignores = append(l.ignores, re)
+	}
+
+	if l.parser == nil {
+		p, err := NewParser(conf.Parser)
+		if err != nil {
+			return nil, err
+		}
+		l.parser = p
 	}
 	return l, nil
 }
 
+// NewParser returns the built-in parser for given parser config
+func NewParser(conf ParserConfig) (Parser, error) {
+	name := conf.Name
+	if name == "" {
+		name = ParserConventional
+		if conf.HeaderPattern != "" {
+			name = ParserRegex
+		}
+	}
+
+	var p Parser
+	switch name {
+	case ParserConventional:
+		if conf.HeaderPattern != "" {
+			return nil, fmt.Errorf("parser '%s' does not support header-pattern", name)
+		}
+		p = newParser()
+	case ParserRegex:
+		if conf.HeaderPattern == "" {
+			return nil, fmt.Errorf("parser '%s' needs header-pattern", name)
+		}
+		regexParser, err := NewRegexParser(conf.HeaderPattern)
+		if err != nil {
+			return nil, err
+		}
+		p = regexParser
+	default:
+		return nil, fmt.Errorf("unknown parser '%s', should be one of [%s %s]", name, ParserConventional, ParserRegex)
+	}
+
+	if conf.Emoji {
+		p = newEmojiParser(p)
+	}
+	return p, nil
+}
+
+// Parse parses the given commitMsg with the linter parser
+func (l *Linter) Parse(commitMsg string) (Commit, error) {
+	return l.parser.Parse(commitMsg)
+}
+
+// IsIgnored checks if commitMsg matches any of the ignore patterns in config
+func (l *Linter) IsIgnored(commitMsg string) bool {
+	for _, re := range l.ignores {
+		if re.MatchString(commitMsg) {
+			return true
+		}
+	}
+	return false
+}
+
+// CommitType returns the type of msg, aliases of enabled TypeAliasRule rules
+// are resolved to their type
+func (l *Linter) CommitType(msg Commit) string {
+	for _, rule := range l.rules {
+		aliasRule, ok := rule.(TypeAliasRule)
+		if !ok {
+			continue
+		}
+		if typ, ok := aliasRule.AliasType(msg.Type()); ok {
+			return typ
+		}
+	}
+	return msg.Type()
+}
+
 // ParseAndLint checks the given commitMsg string against rules
+// ignored commit messages are not parsed and has no issues
 func (l *Linter) ParseAndLint(commitMsg string) (*Result, error) {
+	if l.IsIgnored(commitMsg) {
+		return newResult(commitMsg), nil
+	}
+
 	msg, err := l.parser.Parse(commitMsg)
 	if err != nil {
-		issues := l.parserErrorRule(commitMsg, err)
-		return newResult(commitMsg, issues...), nil
+		return l.lintPartial(newSimpleCommit(commitMsg), err)
 	}
 	return l.Lint(msg)
 }
 
+// ParseAndLintAuthored checks the given commitMsg written by author against rules
+// rules can get the author by asserting the commit to AuthoredCommit
+func (l *Linter) ParseAndLintAuthored(commitMsg string, author Signature) (*Result, error) {
+	_, result, err := l.ParseAndLintCommit(commitMsg, author)
+	return result, err
+}
+
+// ParseAndLintCommit is same as ParseAndLintAuthored, it returns the parsed
+// commit too, so that callers need not parse it again. commit is nil if
+// commitMsg is ignored or can not be parsed
+func (l *Linter) ParseAndLintCommit(commitMsg string, author Signature) (Commit, *Result, error) {
+	if l.IsIgnored(commitMsg) {
+		return nil, newResult(commitMsg), nil
+	}
+
+	msg, err := l.parser.Parse(commitMsg)
+	if err != nil {
+		result, lintErr := l.lintPartial(WithAuthor(newSimpleCommit(commitMsg), author), err)
+		return nil, result, lintErr
+	}
+
+	result, err := l.Lint(WithAuthor(msg, author))
+	return msg, result, err
+}
+
 // Lint checks the given Commit against rules
 func (l *Linter) Lint(msg Commit) (*Result, error) {
+	if l.IsIgnored(msg.Message()) {
+		return newResult(msg.Message()), nil
+	}
+
 	issues := make([]*Issue, 0, len(l.rules))
 
 	for _, rule := range l.rules {
@@ -45,6 +201,57 @@ func (l *Linter) Lint(msg Commit) (*Result, error) {
 	return newResult(msg.Message(), issues...), nil
 }
 
+// lintPartial checks the partially parsed commit against rules which
+// does not need the header, along with the parser error
+func (l *Linter) lintPartial(msg Commit, parseErr error) (*Result, error) {
+	issues := []*Issue{l.parserErrorRule(msg.Message(), parseErr)}
+
+	for _, rule := range l.rules {
+		partialRule, ok := rule.(PartialRule)
+		if !ok || !partialRule.CanValidatePartial() {
+			continue
+		}
+		severity := l.conf.GetSeverity(rule.Name())
+		issue, isValid := l.runRule(rule, severity, msg)
+		if !isValid {
+			issues = append(issues, issue)
+		}
+	}
+
+	return newResult(msg.Message(), issues...), nil
+}
+
+// Fix applies the fixes suggested by rules to the given commitMsg
+// one at a time, until no more fixable issues are found
+// returns the fixed commit message, same as commitMsg if nothing to fix
+func (l *Linter) Fix(commitMsg string) (string, error) {
+	// each fix can change the message, lint again after every fix
+	// bounded by number of rules to avoid rules fixing each other endlessly
+	for i := 0; i <= len(l.rules); i++ {
+		result, err := l.ParseAndLint(commitMsg)
+		if err != nil {
+			return "", err
+		}
+
+		fixedMsg, ok := firstFix(commitMsg, result.Issues())
+		if !ok {
+			break
+		}
+		commitMsg = fixedMsg
+	}
+	return commitMsg, nil
+}
+
+func firstFix(commitMsg string, issues []*Issue) (string, bool) {
+	for _, issue := range issues {
+		fixedMsg := issue.Fix()
+		if fixedMsg != "" && fixedMsg != commitMsg {
+			return fixedMsg, true
+		}
+	}
+	return "", false
+}
+
 func (l *Linter) runRule(rule Rule, severity Severity, msg Commit) (*Issue, bool) {
 	issue, isValid := rule.Validate(msg)
 	if isValid {
@@ -56,9 +263,30 @@ func (l *Linter) runRule(rule Rule, severity Severity, msg Commit) (*Issue, bool
 	return issue, false
 }
 
-func (l *Linter) parserErrorRule(commitMsg string, err error) []*Issue {
+func (l *Linter) parserErrorRule(commitMsg string, err error) *Issue {
 	issue := NewIssue(err.Error())
+
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) && parseErr.Column > 0 {
+		// point to the error location in header
+		marker := strings.Repeat(" ", parseErr.Column-1) + "^"
+		issue = NewIssue(err.Error(), parseErr.Header, marker)
+
+		if parseErr.Suggestion != "" {
+			issue.additionalInfos = append(issue.additionalInfos, fmt.Sprintf("did you mean '%s'?", parseErr.Suggestion))
+			issue = issue.WithSuggestions(parseErr.Suggestion).WithFix(replaceHeader(commitMsg, parseErr.Suggestion))
+		}
+	}
+
 	issue.ruleName = "parser"
 	issue.severity = SeverityError
-	return []*Issue{issue}
+	return issue
+}
+
+func replaceHeader(commitMsg, header string) string {
+	_, rest, hasRest := strings.Cut(commitMsg, "\n")
+	if !hasRest {
+		return header
+	}
+	return header + "\n" + rest
 }
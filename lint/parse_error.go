@@ -0,0 +1,142 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseError represent a commit message parse error with its location
+type ParseError struct {
+	// Line of the error in commit message, starts from 1
+	Line int
+
+	// Column of the error in the line, starts from 1, 0 if not known
+	Column int
+
+	// Reason of the error, like 'missing colon after type'
+	Reason string
+
+	// Header is the header which failed to parse
+	Header string
+
+	// Suggestion is the corrected header, empty if no suggestion
+	Suggestion string
+}
+
+func (e *ParseError) Error() string {
+	if e.Column == 0 {
+		return e.Reason
+	}
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Reason)
+}
+
+// conventionalHeaderError returns a ParseError explaining why the conventional
+// commit header in msg is invalid, err is the error returned by the parser
+func conventionalHeaderError(msg string, err error) *ParseError {
+	header, _, _ := strings.Cut(msg, "\n")
+
+	parseErr := checkConventionalHeader(header)
+	if parseErr == nil {
+		// header looks fine, the error is not about the header
+		return &ParseError{Line: 1, Reason: err.Error(), Header: header}
+	}
+
+	// suggest only if the suggested header has no other errors
+	if parseErr.Suggestion != "" && checkConventionalHeader(parseErr.Suggestion) != nil {
+		parseErr.Suggestion = ""
+	}
+	return parseErr
+}
+
+// headerSeparators are commonly used instead of colon after type
+var headerSeparators = []string{" - ", " -", "-", ";", " |"}
+
+// checkConventionalHeader checks header against 'type(scope)!: description'
+// returns nil if header is valid
+func checkConventionalHeader(header string) *ParseError {
+	newErr := func(pos int, reason, suggestion string) *ParseError {
+		return &ParseError{
+			Line:       1,
+			Column:     utf8.RuneCountInString(header[:pos]) + 1,
+			Reason:     reason,
+			Header:     header,
+			Suggestion: suggestion,
+		}
+	}
+
+	if strings.TrimSpace(header) == "" {
+		return newErr(0, "header is empty", "")
+	}
+
+	if trimmed := strings.TrimLeft(header, " \t"); trimmed != header {
+		return newErr(0, "header should not start with whitespace", trimmed)
+	}
+
+	pos := 0
+	for pos < len(header) && isTypeChar(header[pos]) {
+		pos++
+	}
+	if pos == 0 {
+		return newErr(0, "type is missing", "")
+	}
+	typ := header[:pos]
+
+	if pos < len(header) && header[pos] == ')' {
+		return newErr(pos, "unbalanced parentheses, scope is not opened with '('", "")
+	}
+
+	if pos < len(header) && header[pos] == '(' {
+		end := strings.IndexByte(header[pos:], ')')
+		if end < 0 {
+			return newErr(pos, "unbalanced parentheses, scope is not closed with ')'", "")
+		}
+		end += pos
+
+		scope := header[pos+1 : end]
+		if strings.ContainsAny(scope, "()") {
+			return newErr(pos, "unbalanced parentheses in scope", "")
+		}
+		if strings.TrimSpace(scope) == "" {
+			return newErr(pos, "scope is empty", typ+header[end+1:])
+		}
+		pos = end + 1
+	}
+
+	if pos < len(header) && header[pos] == '!' {
+		pos++
+	}
+	prefix := header[:pos]
+	rest := header[pos:]
+
+	if desc, ok := strings.CutPrefix(strings.TrimLeft(rest, " \t"), ":"); ok && rest[0] != ':' {
+		return newErr(pos, "unexpected space before colon", prefix+": "+strings.TrimLeft(desc, " \t"))
+	}
+
+	if !strings.HasPrefix(rest, ":") {
+		suggestion := ""
+		for _, sep := range headerSeparators {
+			if desc, ok := strings.CutPrefix(rest, sep); ok {
+				suggestion = prefix + ": " + strings.TrimSpace(desc)
+				break
+			}
+		}
+		return newErr(pos, fmt.Sprintf("missing colon after '%s'", prefix), suggestion)
+	}
+
+	pos++
+	desc := header[pos:]
+	switch {
+	case strings.TrimSpace(desc) == "":
+		return newErr(pos, "description is empty", "")
+	case !strings.HasPrefix(desc, " "):
+		return newErr(pos, "missing space after colon", prefix+": "+desc)
+	case strings.HasPrefix(desc, "  ") || strings.HasPrefix(desc, " \t"):
+		return newErr(pos, "more than one space after colon", prefix+": "+strings.TrimLeft(desc, " \t"))
+	}
+	return nil
+}
+
+func isTypeChar(ch byte) bool {
+	return ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' || ch == '-' || ch == '_'
+}
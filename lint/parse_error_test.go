@@ -0,0 +1,99 @@
+package lint
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckConventionalHeader(t *testing.T) {
+	tests := []struct {
+		header     string
+		column     int
+		suggestion string
+	}{
+		{header: "feat(api): add login"},
+		{header: "feat!: drop node 16"},
+		{header: "", column: 1},
+		{header: " feat: add", column: 1, suggestion: "feat: add"},
+		{header: "(api): add", column: 1},
+		{header: "feat(api: add", column: 5},
+		{header: "feat)api: add", column: 5},
+		{header: "feat(a(b)): add", column: 5},
+		{header: "feat(): add", column: 5, suggestion: "feat: add"},
+		{header: "feat add login", column: 5},
+		{header: "feat - add login", column: 5, suggestion: "feat: add login"},
+		{header: "fix(api); handle nil", column: 9, suggestion: "fix(api): handle nil"},
+		{header: "feat :add", column: 5, suggestion: "feat: add"},
+		{header: "feat:add", column: 6, suggestion: "feat: add"},
+		{header: "feat:   add", column: 6, suggestion: "feat: add"},
+		{header: "feat: ", column: 6},
+		{header: "fix(ü):add", column: 8, suggestion: "fix(ü): add"},
+	}
+
+	for _, test := range tests {
+		parseErr := checkConventionalHeader(test.header)
+		if test.column == 0 {
+			if parseErr != nil {
+				t.Errorf("%q: expected no error, got %v", test.header, parseErr)
+			}
+			continue
+		}
+		if parseErr == nil {
+			t.Errorf("%q: expected error at column %d", test.header, test.column)
+			continue
+		}
+		if parseErr.Column != test.column || parseErr.Suggestion != test.suggestion {
+			t.Errorf("%q: expected column %d and suggestion %q, got %d and %q (%s)", test.header, test.column, test.suggestion, parseErr.Column, parseErr.Suggestion, parseErr.Reason)
+		}
+	}
+}
+
+func TestLintPartial(t *testing.T) {
+	conf := &Config{Severity: SeverityConfig{Default: SeverityError}}
+	l, err := New(conf, []Rule{&partialTestRule{}, &headerTestRule{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := l.ParseAndLint("feat:add login\n\nbody")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issues := result.Issues()
+	if len(issues) != 2 || issues[0].RuleName() != "parser" || issues[1].RuleName() != "partial" {
+		t.Fatalf("expected parser and partial issues, got %v", issues)
+	}
+
+	if issues[0].Fix() != "feat: add login\n\nbody" {
+		t.Errorf("unexpected fix %q", issues[0].Fix())
+	}
+
+	var parseErr *ParseError
+	_, err = l.parser.Parse("feat:add login")
+	if !errors.As(err, &parseErr) || parseErr.Reason != "missing space after colon" {
+		t.Errorf("expected missing space error, got %v", err)
+	}
+}
+
+type partialTestRule struct{}
+
+func (r *partialTestRule) Name() string                    { return "partial" }
+func (r *partialTestRule) Apply(setting RuleSetting) error { return nil }
+func (r *partialTestRule) CanValidatePartial() bool        { return true }
+
+func (r *partialTestRule) Validate(msg Commit) (*Issue, bool) {
+	if msg.Body() != "body" || msg.Type() != "" {
+		return nil, true
+	}
+	return NewIssue("body found in partial commit"), false
+}
+
+type headerTestRule struct{}
+
+func (r *headerTestRule) Name() string                    { return "header" }
+func (r *headerTestRule) Apply(setting RuleSetting) error { return nil }
+
+func (r *headerTestRule) Validate(msg Commit) (*Issue, bool) {
+	return NewIssue("should not run on partial commit"), false
+}
@@ -2,6 +2,8 @@ package lint
 
 import "github.com/zexot-com/commitlint-parser"
 
+var _ TrailerCommit = (*defaultCommit)(nil)
+
 type defaultParser struct {
 	p *parser.Parser
 }
@@ -15,7 +17,7 @@ func newParser() *defaultParser {
 func (p defaultParser) Parse(input string) (Commit, error) {
 	c, err := p.p.Parse(input)
 	if err != nil {
-		return nil, err
+		return nil, conventionalHeaderError(input, err)
 	}
 	wrapC := &defaultCommit{
 		Commit: c,
@@ -37,3 +39,7 @@ func (d *defaultCommit) Notes() []Note {
 
 	return notes
 }
+
+func (d *defaultCommit) Trailers() []Trailer {
+	return ParseTrailers(d.Commit.Message())
+}
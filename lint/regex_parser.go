@@ -0,0 +1,81 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Header pattern group names
+const (
+	groupType        = "type"
+	groupScope       = "scope"
+	groupDescription = "description"
+	groupBreaking    = "breaking"
+)
+
+// regexParser parses the header with a regex having named groups
+// type, scope, description and breaking
+type regexParser struct {
+	pattern *regexp.Regexp
+}
+
+// NewRegexParser returns a Parser which parses the header with given pattern
+// pattern should have atleast one of the named groups type, scope, description
+// and breaking, breaking group matching a non-empty string marks a breaking change
+func NewRegexParser(pattern string) (Parser, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid header pattern: %w", err)
+	}
+
+	hasGroup := false
+	for _, name := range re.SubexpNames() {
+		switch name {
+		case "":
+		case groupType, groupScope, groupDescription, groupBreaking:
+			hasGroup = true
+		default:
+			return nil, fmt.Errorf("unknown group '%s' in header pattern, should be one of [%s %s %s %s]", name, groupType, groupScope, groupDescription, groupBreaking)
+		}
+	}
+	if !hasGroup {
+		return nil, errors.New("header pattern should have atleast one named group type, scope, description or breaking")
+	}
+
+	return &regexParser{pattern: re}, nil
+}
+
+func (p *regexParser) Parse(input string) (Commit, error) {
+	msg := strings.TrimRight(input, "\n\t ")
+	if strings.TrimSpace(msg) == "" {
+		return nil, errors.New("commit message is empty")
+	}
+
+	header, _, _ := strings.Cut(msg, "\n")
+	matches := p.pattern.FindStringSubmatch(header)
+	if matches == nil {
+		return nil, &ParseError{
+			Line:   1,
+			Column: 1,
+			Reason: fmt.Sprintf("header does not match pattern %s", p.pattern),
+			Header: header,
+		}
+	}
+
+	group := func(name string) string {
+		ind := p.pattern.SubexpIndex(name)
+		if ind < 0 {
+			return ""
+		}
+		return matches[ind]
+	}
+
+	c := newSimpleCommit(input)
+	c.typ = group(groupType)
+	c.scope = group(groupScope)
+	c.description = group(groupDescription)
+	c.breaking = c.breaking || group(groupBreaking) != ""
+	return c, nil
+}
@@ -0,0 +1,74 @@
+package lint
+
+import "testing"
+
+func TestRegexParser(t *testing.T) {
+	p, err := NewRegexParser(`^\[(?P<type>[A-Z]+)(?P<breaking>!?)\] (?:(?P<scope>[\w-]+): )?(?P<description>.+)$`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := "[FEAT!] api: add login\n\nbody line\n\nRefs: PAY-1\nBREAKING CHANGE: token removed\n"
+	c, err := p.Parse(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Type() != "FEAT" || c.Scope() != "api" || c.Description() != "add login" || !c.IsBreakingChange() {
+		t.Errorf("unexpected header fields %q %q %q %v", c.Type(), c.Scope(), c.Description(), c.IsBreakingChange())
+	}
+	if c.Body() != "body line" {
+		t.Errorf("expected body %q, got %q", "body line", c.Body())
+	}
+	if c.Footer() != "Refs: PAY-1\nBREAKING CHANGE: token removed" {
+		t.Errorf("unexpected footer %q", c.Footer())
+	}
+	if len(c.Notes()) != 2 || c.Notes()[0].Token() != "Refs" || c.Notes()[0].Value() != "PAY-1" {
+		t.Errorf("unexpected notes %v", c.Notes())
+	}
+
+	_, err = p.Parse("feat: add login")
+	if err == nil {
+		t.Error("expected error for header not matching pattern")
+	}
+
+	jira, err := NewRegexParser(`^(?P<scope>[A-Z]+-[0-9]+) \| (?P<description>.+)$`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err = jira.Parse("PAY-123 | add login")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Type() != "" || c.Scope() != "PAY-123" || c.Description() != "add login" || c.IsBreakingChange() {
+		t.Errorf("unexpected header fields %q %q %q %v", c.Type(), c.Scope(), c.Description(), c.IsBreakingChange())
+	}
+}
+
+func TestNewParser(t *testing.T) {
+	invalids := []ParserConfig{
+		{Name: "unknown"},
+		{Name: ParserRegex},
+		{Name: ParserConventional, HeaderPattern: `^(?P<description>.+)$`},
+		{HeaderPattern: `^(?P<subject>.+)$`},
+		{HeaderPattern: `^(.+)$`},
+		{HeaderPattern: `^(?P<description>.+$`},
+	}
+	for _, conf := range invalids {
+		if _, err := NewParser(conf); err == nil {
+			t.Errorf("expected error for %+v", conf)
+		}
+	}
+
+	p, err := NewParser(ParserConfig{HeaderPattern: `^(?P<description>.+)$`, Emoji: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := p.Parse(":bug: fix login")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if CommitEmoji(c) != ":bug:" || c.Description() != "fix login" {
+		t.Errorf("unexpected emoji %q and description %q", CommitEmoji(c), c.Description())
+	}
+}
@@ -1,5 +1,7 @@
 package lint
 
+import "strings"
+
 // Result holds a linter result
 type Result struct {
 	input  string
@@ -7,10 +9,53 @@ type Result struct {
 }
 
 func newResult(input string, issues ...*Issue) *Result {
-	return &Result{
-		input:  input,
+	r := &Result{
 		issues: issues,
 	}
+	r.input = r.Redacted(input)
+
+	// values found by one rule can be in issues of other rules,
+	// like a token in trailer-value-pattern description
+	for _, issue := range issues {
+		issue.description = r.Redacted(issue.description)
+		issue.additionalInfos = r.redactedAll(issue.additionalInfos)
+		issue.suggestions = r.redactedAll(issue.suggestions)
+	}
+	return r
+}
+
+// Redacted returns s with the sensitive values reported by issues hidden
+// Input, descriptions, infos and suggestions are already redacted. Fix is
+// not, as it is applied to the message, formatters should redact it
+func (r *Result) Redacted(s string) string {
+	for _, issue := range r.issues {
+		for _, val := range issue.redactions {
+			s = strings.ReplaceAll(s, val, Redact(val))
+		}
+	}
+	return s
+}
+
+// redactedAll returns copy of strs redacted, rules can pass shared slices
+func (r *Result) redactedAll(strs []string) []string {
+	if strs == nil {
+		return nil
+	}
+	out := make([]string, len(strs))
+	for i, s := range strs {
+		out[i] = r.Redacted(s)
+	}
+	return out
+}
+
+// Redact returns the masked form of a sensitive value
+// short values are masked completely, long values keep first 4 chars
+func Redact(val string) string {
+	runes := []rune(val)
+	if len(runes) <= 8 {
+		return "****"
+	}
+	return string(runes[:4]) + "****"
 }
 
 // Input returns the input commit message
@@ -28,6 +73,12 @@ type Issue struct {
 	description string
 
 	additionalInfos []string
+
+	fix string
+
+	suggestions []string
+
+	redactions []string
 }
 
 // NewIssue returns a new issue
@@ -49,3 +100,33 @@ func (r *Issue) Description() string { return r.description }
 
 // Infos returns additional infos about the issue
 func (r *Issue) Infos() []string { return r.additionalInfos }
+
+// WithFix sets the commit message which fixes the issue
+// it returns the issue to allow chaining with NewIssue
+func (r *Issue) WithFix(fixedMsg string) *Issue {
+	r.fix = fixedMsg
+	return r
+}
+
+// Fix returns the commit message which fixes the issue
+// empty if the issue cannot be fixed automatically
+func (r *Issue) Fix() string { return r.fix }
+
+// WithSuggestions sets the values suggested to replace the invalid value
+// like allowed types close to the given type, closest first
+// it returns the issue to allow chaining with NewIssue
+func (r *Issue) WithSuggestions(suggestions ...string) *Issue {
+	r.suggestions = append(r.suggestions, suggestions...)
+	return r
+}
+
+// Suggestions returns the suggested values, closest first
+func (r *Issue) Suggestions() []string { return r.suggestions }
+
+// WithRedactions marks the values to be redacted from the result input
+// so that formatters do not print sensitive data found by the rule
+// it returns the issue to allow chaining with NewIssue
+func (r *Issue) WithRedactions(values ...string) *Issue {
+	r.redactions = append(r.redactions, values...)
+	return r
+}
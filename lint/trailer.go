@@ -0,0 +1,125 @@
+package lint
+
+import (
+	"regexp"
+	"strings"
+)
+
+// trailerRe matches the first line of a trailer
+// 'Token: value', 'Token #value' and 'BREAKING CHANGE: value'
+var trailerRe = regexp.MustCompile(`^(BREAKING CHANGE|[A-Za-z0-9][A-Za-z0-9-]*)[ \t]*(:|[ \t]#)[ \t]?(.*)$`)
+
+// gitTrailerPrefixes are trailers generated by git, a paragraph containing them
+// is considered a trailer block even if it has some non trailer lines
+var gitTrailerPrefixes = []string{"Signed-off-by: ", "(cherry picked from commit "}
+
+// Trailer represent a git trailer in commit footer
+// as parsed by 'git interpret-trailers'
+type Trailer struct {
+	// Token of the trailer as written in the message
+	Token string
+
+	// Separator between token and value, ":" or "#"
+	Separator string
+
+	// Value of the trailer, continuation lines are joined with newline
+	Value string
+
+	// Raw text of the trailer including continuation lines
+	Raw string
+
+	// Line is the line number of the trailer in commit message, starts from 1
+	Line int
+}
+
+// Key returns normalized token to compare trailers
+// 'BREAKING CHANGE' and 'BREAKING-CHANGE' are treated as same token
+func (t Trailer) Key() string {
+	return TrailerKey(t.Token)
+}
+
+// TrailerKey returns normalized token, same as Trailer.Key, to compare
+// configured tokens with trailers
+func TrailerKey(token string) string {
+	return strings.ReplaceAll(strings.ToLower(token), " ", "-")
+}
+
+// IsBreakingChange returns true if the trailer is a breaking change note
+func (t Trailer) IsBreakingChange() bool {
+	return t.Key() == "breaking-change"
+}
+
+// CommitTrailers returns the trailers of msg, if msg does not implement
+// TrailerCommit, trailers are parsed from the message
+func CommitTrailers(msg Commit) []Trailer {
+	if tc, ok := msg.(TrailerCommit); ok {
+		return tc.Trailers()
+	}
+	return ParseTrailers(msg.Message())
+}
+
+// ParseTrailers returns trailers in the last paragraph of commit message
+// in the order they are written. It follows 'git interpret-trailers' rules
+//   - lines starting with whitespace continues the previous trailer
+//   - paragraph is a trailer block if all lines are trailers, or atleast 25%
+//     of lines are trailers and one of them is generated by git
+func ParseTrailers(msg string) []Trailer {
+	lines := strings.Split(strings.TrimRight(msg, "\n\t "), "\n")
+
+	// find the last paragraph, header is never part of trailers
+	start := len(lines)
+	for start > 1 && strings.TrimSpace(lines[start-1]) != "" {
+		start--
+	}
+	if start <= 1 || start == len(lines) {
+		return nil
+	}
+
+	var trailers []Trailer
+	var nonTrailers int
+	var hasGitTrailer bool
+
+	for i := start; i < len(lines); i++ {
+		line := lines[i]
+
+		isContinuation := strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+		if isContinuation && len(trailers) > 0 {
+			last := &trailers[len(trailers)-1]
+			last.Value += "\n" + strings.TrimSpace(line)
+			last.Raw += "\n" + line
+			continue
+		}
+
+		matches := trailerRe.FindStringSubmatch(line)
+		if matches == nil {
+			nonTrailers++
+			continue
+		}
+
+		for _, prefix := range gitTrailerPrefixes {
+			if strings.HasPrefix(line, prefix) {
+				hasGitTrailer = true
+			}
+		}
+
+		trailers = append(trailers, Trailer{
+			Token:     matches[1],
+			Separator: strings.TrimSpace(matches[2]),
+			Value:     strings.TrimSpace(matches[3]),
+			Raw:       line,
+			Line:      i + 1,
+		})
+	}
+
+	if len(trailers) == 0 {
+		return nil
+	}
+
+	if nonTrailers > 0 {
+		total := len(trailers) + nonTrailers
+		if !hasGitTrailer || len(trailers)*4 < total {
+			return nil
+		}
+	}
+	return trailers
+}
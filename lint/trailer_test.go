@@ -0,0 +1,86 @@
+package lint
+
+import "testing"
+
+func TestParseTrailers(t *testing.T) {
+	tests := []struct {
+		name   string
+		msg    string
+		tokens []string
+		values []string
+	}{
+		{
+			name: "no footer",
+			msg:  "feat: add login\n\nbody text",
+		},
+		{
+			name:   "header only paragraph",
+			msg:    "Signed-off-by: A <a@b.c>",
+			tokens: nil,
+		},
+		{
+			name:   "separators and alias",
+			msg:    "feat: x\n\nbody\n\nCloses #123\nBREAKING-CHANGE: api removed\nBREAKING CHANGE: other",
+			tokens: []string{"Closes", "BREAKING-CHANGE", "BREAKING CHANGE"},
+			values: []string{"123", "api removed", "other"},
+		},
+		{
+			name:   "continuation line",
+			msg:    "feat: x\n\nBREAKING CHANGE: first line\n  second line\nRefs: PAY-1",
+			tokens: []string{"BREAKING CHANGE", "Refs"},
+			values: []string{"first line\nsecond line", "PAY-1"},
+		},
+		{
+			name: "paragraph with text",
+			msg:  "feat: x\n\nsome text here\nRefs: PAY-1",
+		},
+		{
+			name:   "git generated trailer with text",
+			msg:    "feat: x\n\nsome text here\nSigned-off-by: A <a@b.c>",
+			tokens: []string{"Signed-off-by"},
+			values: []string{"A <a@b.c>"},
+		},
+	}
+
+	for _, test := range tests {
+		trailers := ParseTrailers(test.msg)
+		if len(trailers) != len(test.tokens) {
+			t.Errorf("%s: expected %d trailers, got %d", test.name, len(test.tokens), len(trailers))
+			continue
+		}
+		for i, tr := range trailers {
+			if tr.Token != test.tokens[i] || tr.Value != test.values[i] {
+				t.Errorf("%s: expected %q: %q, got %q: %q", test.name, test.tokens[i], test.values[i], tr.Token, tr.Value)
+			}
+		}
+	}
+
+	trailers := ParseTrailers("feat: x\n\nbody\n\nBREAKING-CHANGE: x")
+	if len(trailers) != 1 || !trailers[0].IsBreakingChange() || trailers[0].Line != 5 {
+		t.Errorf("expected breaking change trailer in line 5, got %#v", trailers)
+	}
+}
+
+// plainCommit implements only Commit, like commits of external parsers
+type plainCommit struct {
+	Commit
+	message string
+}
+
+func (c *plainCommit) Message() string { return c.message }
+
+func TestCommitTrailers(t *testing.T) {
+	msg := "feat: x\n\nRefs: PAY-1"
+
+	commits := map[string]Commit{
+		"simple commit":   newSimpleCommit(msg),
+		"plain commit":    &plainCommit{message: msg},
+		"authored commit": WithAuthor(&plainCommit{message: msg}, Signature{Name: "A"}),
+	}
+	for name, c := range commits {
+		trailers := CommitTrailers(c)
+		if len(trailers) != 1 || trailers[0].Token != "Refs" || trailers[0].Value != "PAY-1" {
+			t.Errorf("%s: unexpected trailers %+v", name, trailers)
+		}
+	}
+}
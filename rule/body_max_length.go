@@ -2,26 +2,39 @@ package rule
 
 import "github.com/zexot-com/commitlint/lint"
 
-var _ lint.Rule = (*BodyMaxLenRule)(nil)
+var (
+	_ lint.PartialRule = (*BodyMaxLenRule)(nil)
+	_ lint.SchemaRule  = (*BodyMaxLenRule)(nil)
+)
 
 // BodyMaxLenRule to validate max length of body
 type BodyMaxLenRule struct {
 	CheckLen int
+	Unit     LengthUnit
 }
 
 // Name return name of the rule
 func (r *BodyMaxLenRule) Name() string { return "body-max-length" }
 
+// CanValidatePartial returns true, a long body is reported even if header is invalid
+func (r *BodyMaxLenRule) CanValidatePartial() bool { return true }
+
 // Apply sets the needed argument for the rule
 func (r *BodyMaxLenRule) Apply(setting lint.RuleSetting) error {
 	err := setIntArg(&r.CheckLen, setting.Argument)
 	if err != nil {
 		return errInvalidArg(r.Name(), err)
 	}
-	return nil
+	return setUnitFlag(r.Name(), &r.Unit, setting.Flags)
 }
 
+// ArgumentSchema returns the schema of length argument
+func (r *BodyMaxLenRule) ArgumentSchema() lint.Schema { return intSchema() }
+
+// FlagsSchema returns the schema of unit flag
+func (r *BodyMaxLenRule) FlagsSchema() map[string]lint.Schema { return unitFlagSchema() }
+
 // Validate validates BodyMaxLenRule
 func (r *BodyMaxLenRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
-	return validateMaxLen("body", r.CheckLen, msg.Body())
+	return validateMaxLen("body", r.Unit, r.CheckLen, msg.Body())
 }
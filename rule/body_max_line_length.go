@@ -4,26 +4,39 @@ import (
 	"github.com/zexot-com/commitlint/lint"
 )
 
-var _ lint.Rule = (*BodyMaxLineLenRule)(nil)
+var (
+	_ lint.PartialRule = (*BodyMaxLineLenRule)(nil)
+	_ lint.SchemaRule  = (*BodyMaxLineLenRule)(nil)
+)
 
 // BodyMaxLineLenRule to validate max line length of body
 type BodyMaxLineLenRule struct {
 	CheckLen int
+	Unit     LengthUnit
 }
 
 // Name return name of the rule
 func (r *BodyMaxLineLenRule) Name() string { return "body-max-line-length" }
 
+// CanValidatePartial returns true as only lines after header are checked
+func (r *BodyMaxLineLenRule) CanValidatePartial() bool { return true }
+
 // Apply sets the needed argument for the rule
 func (r *BodyMaxLineLenRule) Apply(setting lint.RuleSetting) error {
 	err := setIntArg(&r.CheckLen, setting.Argument)
 	if err != nil {
 		return errInvalidArg(r.Name(), err)
 	}
-	return nil
+	return setUnitFlag(r.Name(), &r.Unit, setting.Flags)
 }
 
+// ArgumentSchema returns the schema of length argument
+func (r *BodyMaxLineLenRule) ArgumentSchema() lint.Schema { return intSchema() }
+
+// FlagsSchema returns the schema of unit flag
+func (r *BodyMaxLineLenRule) FlagsSchema() map[string]lint.Schema { return unitFlagSchema() }
+
 // Validate validates BodyMaxLineLenRule rule
 func (r *BodyMaxLineLenRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
-	return validateMaxLineLength("body", r.CheckLen, msg.Body())
+	return validateMaxLineLength("body", r.Unit, r.CheckLen, msg.Body())
 }
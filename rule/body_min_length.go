@@ -2,26 +2,39 @@ package rule
 
 import "github.com/zexot-com/commitlint/lint"
 
-var _ lint.Rule = (*BodyMinLenRule)(nil)
+var (
+	_ lint.PartialRule = (*BodyMinLenRule)(nil)
+	_ lint.SchemaRule  = (*BodyMinLenRule)(nil)
+)
 
 // BodyMinLenRule to validate min length of body
 type BodyMinLenRule struct {
 	CheckLen int
+	Unit     LengthUnit
 }
 
 // Name return name of the rule
 func (r *BodyMinLenRule) Name() string { return "body-min-length" }
 
+// CanValidatePartial returns true as body is split from header without parsing it
+func (r *BodyMinLenRule) CanValidatePartial() bool { return true }
+
 // Apply sets the needed argument for the rule
 func (r *BodyMinLenRule) Apply(setting lint.RuleSetting) error {
 	err := setIntArg(&r.CheckLen, setting.Argument)
 	if err != nil {
 		return errInvalidArg(r.Name(), err)
 	}
-	return nil
+	return setUnitFlag(r.Name(), &r.Unit, setting.Flags)
 }
 
+// ArgumentSchema returns the schema of length argument
+func (r *BodyMinLenRule) ArgumentSchema() lint.Schema { return intSchema() }
+
+// FlagsSchema returns the schema of unit flag
+func (r *BodyMinLenRule) FlagsSchema() map[string]lint.Schema { return unitFlagSchema() }
+
 // Validate validates BodyMinLenRule
 func (r *BodyMinLenRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
-	return validateMinLen("body", r.CheckLen, msg.Body())
+	return validateMinLen("body", r.Unit, r.CheckLen, msg.Body())
 }
@@ -0,0 +1,280 @@
+//go:build ignore
+
+// genwords generates words.txt, the english dictionary of spelling rule
+//
+//	go run genwords.go -scowl path/to/scowl/final > words.txt
+//
+// Words are taken from english and american word lists of SCOWL
+// (http://wordlist.aspell.net) up to given size, 60 by default. Verb forms
+// in verbs.txt and extraWords, which are common in commit messages, are added.
+//
+// Words used in comments of Go standard library are an optional add-on
+//
+//	go run genwords.go -scowl path/to/scowl/final -goroot "$(go env GOROOT)" > words.txt
+//
+// A comment word is kept if it is used at least minCount times in at least
+// minPackages packages and it is not a rare variant of a frequent word, so
+// that typos and identifiers are dropped.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	minCount    = 3
+	minPackages = 2
+
+	// rare words are dropped as typo, if a word with swapped, doubled or
+	// undoubled letter is typoRatio times more frequent, like 'wil' for 'will'
+	maxTypoCount = 20
+	typoRatio    = 50
+
+	// words shorter than minLength are not checked by spelling rule
+	minLength = 3
+)
+
+// SCOWL word list categories and sizes, files are named like 'english-words.50'
+var (
+	scowlCategories = []string{"english-words", "american-words"}
+	scowlSizes      = []int{10, 20, 35, 40, 50, 55, 60, 70, 80, 95}
+)
+
+var (
+	lettersRe = regexp.MustCompile(`[A-Za-z]+`)
+
+	// wordRe matches lower case or capitalized words
+	wordRe = regexp.MustCompile(`^[A-Z]?[a-z]+$`)
+)
+
+// extraWords are common in commit messages but missing in word lists
+const extraWords = `
+analytics api apis auth autofix avatar backend backlog blog bugfix button
+buttons colour dark filed modal oops responsive theme themes
+changelog checkbox chore config configs css dashboard dedupe deduplicate
+dev devops dockerfile dropdown email emails env eslint favicon frontend
+gitignore gitmoji hotfix html json kubernetes linter linters login logout
+middleware mobile navbar oauth onboarding pagination plugin plugins readme
+repo repos signin signup sso stylesheet subcommand subcommands sidebar
+timestamp todo todos tooltip typo typos ui url urls username usernames
+webhook webhooks workflow workflows yaml
+`
+
+func main() {
+	scowl := flag.String("scowl", "", "path of SCOWL final directory")
+	size := flag.Int("size", 60, "max SCOWL size of words")
+	goroot := flag.String("goroot", "", "path of GOROOT, to add words of Go comments")
+	verbs := flag.String("verbs", "verbs.txt", "path of verbs.txt")
+	flag.Parse()
+
+	if *scowl == "" && *goroot == "" {
+		log.Fatal("-scowl or -goroot is required")
+	}
+
+	words := make(map[string]struct{})
+	var sources []string
+
+	if *scowl != "" {
+		err := addScowlWords(words, *scowl, *size)
+		if err != nil {
+			log.Fatal(err)
+		}
+		sources = append(sources,
+			fmt.Sprintf("# SCOWL size %d english and american words, http://wordlist.aspell.net", *size),
+			"# SCOWL is Copyright Kevin Atkinson, permissive license, http://wordlist.aspell.net/scowl-readme/")
+	}
+
+	if *goroot != "" {
+		version, err := addGoWords(words, *goroot)
+		if err != nil {
+			log.Fatal(err)
+		}
+		sources = append(sources,
+			fmt.Sprintf("# Words used in comments of Go standard library %s", version),
+			"# Go source is Copyright 2009 The Go Authors, BSD-3-Clause license, https://go.dev/LICENSE")
+	}
+
+	for _, w := range strings.Fields(extraWords) {
+		words[w] = struct{}{}
+	}
+
+	verbFile, err := os.Open(*verbs)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer verbFile.Close()
+	scanner := bufio.NewScanner(verbFile)
+	for scanner.Scan() {
+		for _, w := range strings.Fields(scanner.Text()) {
+			words[w] = struct{}{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+
+	list := make([]string, 0, len(words))
+	for w := range words {
+		list = append(list, w)
+	}
+	sort.Strings(list)
+
+	fmt.Println("# generated by genwords.go")
+	for _, src := range sources {
+		fmt.Println(src)
+	}
+	for _, w := range list {
+		fmt.Println(w)
+	}
+}
+
+// addScowlWords adds lower case words of SCOWL english and american word
+// lists up to size, possessives, abbreviations and words with accents are
+// skipped. Lists are latin-1 encoded, so non ascii words are dropped
+func addScowlWords(words map[string]struct{}, dir string, size int) error {
+	found := false
+	for _, category := range scowlCategories {
+		for _, s := range scowlSizes {
+			if s > size {
+				break
+			}
+			content, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.%d", category, s)))
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			found = true
+
+			for _, w := range strings.Fields(string(content)) {
+				if len(w) >= minLength && wordRe.MatchString(w) {
+					words[strings.ToLower(w)] = struct{}{}
+				}
+			}
+		}
+	}
+	if !found {
+		return fmt.Errorf("no SCOWL word lists found in %s", dir)
+	}
+	return nil
+}
+
+// addGoWords adds words used in comments of Go standard library in goroot
+// returns the Go version
+func addGoWords(words map[string]struct{}, goroot string) (string, error) {
+	version, err := os.ReadFile(filepath.Join(goroot, "VERSION"))
+	if err != nil {
+		return "", err
+	}
+
+	counts := make(map[string]int)
+	packages := make(map[string]map[string]struct{})
+
+	src := filepath.Join(goroot, "src")
+	err = filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && (d.Name() == "testdata" || d.Name() == "vendor") {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		f, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ParseComments)
+		if err != nil {
+			// some files are not valid go, like templates
+			return nil
+		}
+		pkg := filepath.Dir(path)
+		for _, group := range f.Comments {
+			for _, w := range commentWords(group.Text()) {
+				counts[w]++
+				if packages[w] == nil {
+					packages[w] = make(map[string]struct{})
+				}
+				packages[w][pkg] = struct{}{}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for w, c := range counts {
+		if len(w) >= minLength && c >= minCount && len(packages[w]) >= minPackages && !isTypo(w, counts) {
+			words[w] = struct{}{}
+		}
+	}
+	return strings.SplitN(string(version), "\n", 2)[0], nil
+}
+
+// commentWords returns the lower cased words in text, which are not part of
+// identifiers, paths or qualified names like 'os.Open'
+func commentWords(text string) []string {
+	var words []string
+	for _, loc := range lettersRe.FindAllStringIndex(text, -1) {
+		start, end := loc[0], loc[1]
+		if start > 0 && strings.ContainsRune("0123456789_'/.-", rune(text[start-1])) {
+			continue
+		}
+		if end < len(text) && strings.ContainsRune("0123456789_/-", rune(text[end])) {
+			continue
+		}
+		// qualified name, dot followed by letter
+		if end+1 < len(text) && text[end] == '.' && isLetter(text[end+1]) {
+			continue
+		}
+		if w := text[start:end]; wordRe.MatchString(w) {
+			words = append(words, strings.ToLower(w))
+		}
+	}
+	return words
+}
+
+// isTypo checks if w is a rare misspelling of a frequent word
+func isTypo(w string, counts map[string]int) bool {
+	if counts[w] >= maxTypoCount {
+		return false
+	}
+	for _, v := range typoEdits(w) {
+		if counts[v] >= typoRatio*counts[w] {
+			return true
+		}
+	}
+	return false
+}
+
+// typoEdits returns w with adjacent letters swapped, a letter doubled
+// or a doubled letter undoubled
+func typoEdits(w string) []string {
+	var edits []string
+	for i := 0; i < len(w); i++ {
+		edits = append(edits, w[:i+1]+w[i:])
+		if i+1 < len(w) {
+			edits = append(edits, w[:i]+w[i+1:i+2]+w[i:i+1]+w[i+2:])
+			if w[i] == w[i+1] {
+				edits = append(edits, w[:i]+w[i+1:])
+			}
+		}
+	}
+	return edits
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
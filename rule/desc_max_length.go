@@ -2,11 +2,12 @@ package rule
 
 import "github.com/zexot-com/commitlint/lint"
 
-var _ lint.Rule = (*DescriptionMaxLenRule)(nil)
+var _ lint.SchemaRule = (*DescriptionMaxLenRule)(nil)
 
 // DescriptionMaxLenRule to validate max length of type
 type DescriptionMaxLenRule struct {
 	CheckLen int
+	Unit     LengthUnit
 }
 
 // Name return name of the rule
@@ -18,10 +19,16 @@ func (r *DescriptionMaxLenRule) Apply(setting lint.RuleSetting) error {
 	if err != nil {
 		return errInvalidArg(r.Name(), err)
 	}
-	return nil
+	return setUnitFlag(r.Name(), &r.Unit, setting.Flags)
 }
 
+// ArgumentSchema returns the schema of length argument
+func (r *DescriptionMaxLenRule) ArgumentSchema() lint.Schema { return intSchema() }
+
+// FlagsSchema returns the schema of unit flag
+func (r *DescriptionMaxLenRule) FlagsSchema() map[string]lint.Schema { return unitFlagSchema() }
+
 // Validate validates DescriptionMaxLenRule
 func (r *DescriptionMaxLenRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
-	return validateMaxLen("description", r.CheckLen, msg.Description())
+	return validateMaxLen("description", r.Unit, r.CheckLen, msg.Description())
 }
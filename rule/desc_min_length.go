@@ -2,11 +2,12 @@ package rule
 
 import "github.com/zexot-com/commitlint/lint"
 
-var _ lint.Rule = (*DescriptionMinLenRule)(nil)
+var _ lint.SchemaRule = (*DescriptionMinLenRule)(nil)
 
 // DescriptionMinLenRule to validate min length of description
 type DescriptionMinLenRule struct {
 	CheckLen int
+	Unit     LengthUnit
 }
 
 // Name return name of the rule
@@ -18,10 +19,16 @@ func (r *DescriptionMinLenRule) Apply(setting lint.RuleSetting) error {
 	if err != nil {
 		return errInvalidArg(r.Name(), err)
 	}
-	return nil
+	return setUnitFlag(r.Name(), &r.Unit, setting.Flags)
 }
 
+// ArgumentSchema returns the schema of length argument
+func (r *DescriptionMinLenRule) ArgumentSchema() lint.Schema { return intSchema() }
+
+// FlagsSchema returns the schema of unit flag
+func (r *DescriptionMinLenRule) FlagsSchema() map[string]lint.Schema { return unitFlagSchema() }
+
 // Validate validates DescriptionMinLenRule
 func (r *DescriptionMinLenRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
-	return validateMinLen("description", r.CheckLen, msg.Description())
+	return validateMinLen("description", r.Unit, r.CheckLen, msg.Description())
 }
@@ -0,0 +1,67 @@
+package rule
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/zexot-com/commitlint/lint"
+)
+
+var _ lint.SchemaRule = (*DescriptionImperativeRule)(nil)
+
+// DescriptionImperativeRule to validate description starts with a verb in imperative mood
+type DescriptionImperativeRule struct{}
+
+// Name return name of the rule
+func (r *DescriptionImperativeRule) Name() string { return "description-imperative" }
+
+// Apply sets the needed argument for the rule
+func (r *DescriptionImperativeRule) Apply(setting lint.RuleSetting) error {
+	return nil
+}
+
+// ArgumentSchema returns empty schema as rule has no argument, any argument is ignored
+func (r *DescriptionImperativeRule) ArgumentSchema() lint.Schema { return lint.Schema{} }
+
+// FlagsSchema returns nil as rule has no flags
+func (r *DescriptionImperativeRule) FlagsSchema() map[string]lint.Schema { return nil }
+
+// Validate validates DescriptionImperativeRule
+func (r *DescriptionImperativeRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
+	desc := msg.Description()
+	firstWord := strings.TrimFunc(strings.SplitN(strings.TrimSpace(desc), " ", 2)[0], func(ch rune) bool {
+		return !unicode.IsLetter(ch)
+	})
+
+	verb, ok := imperativeVerb(firstWord)
+	if !ok {
+		return nil, true
+	}
+
+	verb = matchCase(firstWord, verb)
+	issueDesc := "description should be in imperative mood"
+	info := fmt.Sprintf("use '%s' instead of '%s'", verb, firstWord)
+	issue := lint.NewIssue(issueDesc, info)
+
+	header := msg.Header()
+	if !strings.HasSuffix(header, desc) {
+		return issue, false
+	}
+
+	fixedDesc := strings.Replace(desc, firstWord, verb, 1)
+	fixedHeader := strings.TrimSuffix(header, desc) + fixedDesc
+	return issue.WithFix(replaceLine(msg.Message(), 1, fixedHeader)), false
+}
+
+// matchCase returns word with same capitalization of first letter as ref
+func matchCase(ref, word string) string {
+	if ref == "" || word == "" {
+		return word
+	}
+	first := []rune(ref)[0]
+	if unicode.IsUpper(first) {
+		return strings.ToUpper(word[:1]) + word[1:]
+	}
+	return word
+}
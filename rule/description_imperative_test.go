@@ -0,0 +1,50 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/zexot-com/commitlint/lint"
+)
+
+func TestDescriptionImperative(t *testing.T) {
+	tests := []struct {
+		name    string
+		msg     string
+		isValid bool
+		info    string
+		fix     string
+	}{
+		{name: "imperative", msg: "fix: add login page", isValid: true},
+		{name: "not a verb", msg: "fix: typo in readme", isValid: true},
+		{name: "past tense", msg: "fix: added login page", info: "use 'add' instead of 'added'", fix: "fix: add login page"},
+		{name: "third person", msg: "feat(api): Fixes null check", info: "use 'Fix' instead of 'Fixes'", fix: "feat(api): Fix null check"},
+		{
+			name: "gerund with body",
+			msg:  "docs: updating guide\n\nMention the new flag.",
+			info: "use 'update' instead of 'updating'",
+			fix:  "docs: update guide\n\nMention the new flag.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &DescriptionImperativeRule{}
+			applyRule(t, r, lint.RuleSetting{})
+
+			issue, isValid := r.Validate(parseCommit(t, tc.msg))
+			if isValid != tc.isValid {
+				t.Fatalf("isValid = %v, want %v, issue %+v", isValid, tc.isValid, issue)
+			}
+			if tc.isValid {
+				return
+			}
+
+			if infos := issue.Infos(); len(infos) != 1 || infos[0] != tc.info {
+				t.Errorf("infos = %q, want %q", infos, tc.info)
+			}
+			if issue.Fix() != tc.fix {
+				t.Errorf("fix = %q, want %q", issue.Fix(), tc.fix)
+			}
+		})
+	}
+}
@@ -8,7 +8,10 @@ import (
 	"github.com/zexot-com/commitlint/lint"
 )
 
-var _ lint.Rule = (*FooterEnumRule)(nil)
+var (
+	_ lint.PartialRule = (*FooterEnumRule)(nil)
+	_ lint.SchemaRule  = (*FooterEnumRule)(nil)
+)
 
 // FooterEnumRule to validate footer tokens
 type FooterEnumRule struct {
@@ -18,6 +21,9 @@ type FooterEnumRule struct {
 // Name return name of the rule
 func (r *FooterEnumRule) Name() string { return "footer-enum" }
 
+// CanValidatePartial returns true as footer tokens do not depend on type or scope
+func (r *FooterEnumRule) CanValidatePartial() bool { return true }
+
 // Apply sets the needed argument for the rule
 func (r *FooterEnumRule) Apply(setting lint.RuleSetting) error {
 	err := setStringArrArg(&r.Tokens, setting.Argument)
@@ -29,6 +35,12 @@ func (r *FooterEnumRule) Apply(setting lint.RuleSetting) error {
 	return nil
 }
 
+// ArgumentSchema returns the schema of allowed footer tokens
+func (r *FooterEnumRule) ArgumentSchema() lint.Schema { return stringArrSchema() }
+
+// FlagsSchema returns nil as rule has no flags
+func (r *FooterEnumRule) FlagsSchema() map[string]lint.Schema { return nil }
+
 // Validate validates FooterEnumRule
 func (r *FooterEnumRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
 	var invalids []string
@@ -45,6 +57,21 @@ func (r *FooterEnumRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
 	}
 
 	desc := fmt.Sprintf("you can use one of %v", r.Tokens)
-	info := fmt.Sprintf("[%s] tokens are not allowed", strings.Join(invalids, ", "))
-	return lint.NewIssue(desc, info), false
+	infos := []string{fmt.Sprintf("[%s] tokens are not allowed", strings.Join(invalids, ", "))}
+
+	var tokenSuggestions []string
+	for _, token := range invalids {
+		suggestions := enumSuggestions(token, r.Tokens)
+		if len(suggestions) > 0 {
+			infos = append(infos, fmt.Sprintf("'%s', %s", token, didYouMean(suggestions)))
+		}
+		tokenSuggestions = suggestions
+	}
+
+	issue := lint.NewIssue(desc, infos...)
+	// suggestions are for a single invalid token, else it is ambiguous
+	if len(invalids) == 1 && len(tokenSuggestions) > 0 {
+		issue = issue.WithSuggestions(tokenSuggestions...)
+	}
+	return issue, false
 }
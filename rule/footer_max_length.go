@@ -2,26 +2,39 @@ package rule
 
 import "github.com/zexot-com/commitlint/lint"
 
-var _ lint.Rule = (*FooterMaxLenRule)(nil)
+var (
+	_ lint.PartialRule = (*FooterMaxLenRule)(nil)
+	_ lint.SchemaRule  = (*FooterMaxLenRule)(nil)
+)
 
 // FooterMaxLenRule to validate max length of footer
 type FooterMaxLenRule struct {
 	CheckLen int
+	Unit     LengthUnit
 }
 
 // Name return name of the rule
 func (r *FooterMaxLenRule) Name() string { return "footer-max-length" }
 
+// CanValidatePartial returns true as footer is parsed separately from header
+func (r *FooterMaxLenRule) CanValidatePartial() bool { return true }
+
 // Apply sets the needed argument for the rule
 func (r *FooterMaxLenRule) Apply(setting lint.RuleSetting) error {
 	err := setIntArg(&r.CheckLen, setting.Argument)
 	if err != nil {
 		return errInvalidArg(r.Name(), err)
 	}
-	return nil
+	return setUnitFlag(r.Name(), &r.Unit, setting.Flags)
 }
 
+// ArgumentSchema returns the schema of length argument
+func (r *FooterMaxLenRule) ArgumentSchema() lint.Schema { return intSchema() }
+
+// FlagsSchema returns the schema of unit flag
+func (r *FooterMaxLenRule) FlagsSchema() map[string]lint.Schema { return unitFlagSchema() }
+
 // Validate validates FooterMaxLenRule
 func (r *FooterMaxLenRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
-	return validateMaxLen("footer", r.CheckLen, msg.Footer())
+	return validateMaxLen("footer", r.Unit, r.CheckLen, msg.Footer())
 }
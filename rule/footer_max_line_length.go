@@ -2,26 +2,39 @@ package rule
 
 import "github.com/zexot-com/commitlint/lint"
 
-var _ lint.Rule = (*FooterMaxLineLenRule)(nil)
+var (
+	_ lint.PartialRule = (*FooterMaxLineLenRule)(nil)
+	_ lint.SchemaRule  = (*FooterMaxLineLenRule)(nil)
+)
 
 // FooterMaxLineLenRule to validate max line length of footer
 type FooterMaxLineLenRule struct {
 	CheckLen int
+	Unit     LengthUnit
 }
 
 // Name return name of the rule
 func (r *FooterMaxLineLenRule) Name() string { return "footer-max-line-length" }
 
+// CanValidatePartial returns true as only lines of footer are checked
+func (r *FooterMaxLineLenRule) CanValidatePartial() bool { return true }
+
 // Apply sets the needed argument for the rule
 func (r *FooterMaxLineLenRule) Apply(setting lint.RuleSetting) error {
 	err := setIntArg(&r.CheckLen, setting.Argument)
 	if err != nil {
 		return errInvalidArg(r.Name(), err)
 	}
-	return nil
+	return setUnitFlag(r.Name(), &r.Unit, setting.Flags)
 }
 
+// ArgumentSchema returns the schema of length argument
+func (r *FooterMaxLineLenRule) ArgumentSchema() lint.Schema { return intSchema() }
+
+// FlagsSchema returns the schema of unit flag
+func (r *FooterMaxLineLenRule) FlagsSchema() map[string]lint.Schema { return unitFlagSchema() }
+
 // Validate validates FooterMaxLineLenRule rule
 func (r *FooterMaxLineLenRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
-	return validateMaxLineLength("footer", r.CheckLen, msg.Footer())
+	return validateMaxLineLength("footer", r.Unit, r.CheckLen, msg.Footer())
 }
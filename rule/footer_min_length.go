@@ -2,26 +2,39 @@ package rule
 
 import "github.com/zexot-com/commitlint/lint"
 
-var _ lint.Rule = (*FooterMinLenRule)(nil)
+var (
+	_ lint.PartialRule = (*FooterMinLenRule)(nil)
+	_ lint.SchemaRule  = (*FooterMinLenRule)(nil)
+)
 
 // FooterMinLenRule to validate min length of footer
 type FooterMinLenRule struct {
 	CheckLen int
+	Unit     LengthUnit
 }
 
 // Name return name of the rule
 func (r *FooterMinLenRule) Name() string { return "footer-min-length" }
 
+// CanValidatePartial returns true, missing footer is reported even if header is invalid
+func (r *FooterMinLenRule) CanValidatePartial() bool { return true }
+
 // Apply sets the needed argument for the rule
 func (r *FooterMinLenRule) Apply(setting lint.RuleSetting) error {
 	err := setIntArg(&r.CheckLen, setting.Argument)
 	if err != nil {
 		return errInvalidArg(r.Name(), err)
 	}
-	return nil
+	return setUnitFlag(r.Name(), &r.Unit, setting.Flags)
 }
 
+// ArgumentSchema returns the schema of length argument
+func (r *FooterMinLenRule) ArgumentSchema() lint.Schema { return intSchema() }
+
+// FlagsSchema returns the schema of unit flag
+func (r *FooterMinLenRule) FlagsSchema() map[string]lint.Schema { return unitFlagSchema() }
+
 // Validate validates FooterMinLenRule
 func (r *FooterMinLenRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
-	return validateMinLen("footer", r.CheckLen, msg.Footer())
+	return validateMinLen("footer", r.Unit, r.CheckLen, msg.Footer())
 }
@@ -10,7 +10,7 @@ import (
 	"github.com/zexot-com/commitlint/lint"
 )
 
-var _ lint.Rule = (*FooterTypeEnumRule)(nil)
+var _ lint.SchemaRule = (*FooterTypeEnumRule)(nil)
 
 // FooterTypeEnumRule to validate footer tokens
 type FooterTypeEnumRule struct {
@@ -108,6 +108,18 @@ func processParam(r lint.Rule, val map[interface{}]interface{}, index int) (*Foo
 	return param, nil
 }
 
+// ArgumentSchema returns the schema of token, types and values params
+func (r *FooterTypeEnumRule) ArgumentSchema() lint.Schema {
+	return paramsSchema(map[string]lint.Schema{
+		"token":  stringSchema(),
+		"types":  stringArrSchema(),
+		"values": stringArrSchema(),
+	})
+}
+
+// FlagsSchema returns nil as rule has no flags
+func (r *FooterTypeEnumRule) FlagsSchema() map[string]lint.Schema { return nil }
+
 // Validate validates FooterTypeEnumRule
 func (r *FooterTypeEnumRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
 	var invalids []string
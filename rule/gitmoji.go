@@ -0,0 +1,63 @@
+package rule
+
+import (
+	_ "embed" // for embedding gitmoji list
+	"strings"
+	"sync"
+)
+
+// gitmojiList is the embedded gitmoji list, each line has tab separated
+// emoji, shortcode, conventional type ('-' if none) and description
+//
+//go:embed data/gitmojis.txt
+var gitmojiList string
+
+// gitmoji represent an entry in gitmoji list
+type gitmoji struct {
+	Emoji     string
+	Shortcode string
+	Type      string
+}
+
+var (
+	gitmojisOnce sync.Once
+	gitmojis     []*gitmoji
+)
+
+// knownGitmojis returns the embedded gitmoji list
+func knownGitmojis() []*gitmoji {
+	gitmojisOnce.Do(func() {
+		for _, line := range strings.Split(gitmojiList, "\n") {
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			fields := strings.Split(line, "\t")
+			if len(fields) < 3 {
+				continue
+			}
+			g := &gitmoji{Emoji: fields[0], Shortcode: fields[1], Type: fields[2]}
+			if g.Type == "-" {
+				g.Type = ""
+			}
+			gitmojis = append(gitmojis, g)
+		}
+	})
+	return gitmojis
+}
+
+// findGitmoji returns the gitmoji for given shortcode or unicode emoji
+func findGitmoji(emoji string) (*gitmoji, bool) {
+	emoji = stripVariation(emoji)
+	for _, g := range knownGitmojis() {
+		if g.Shortcode == emoji || stripVariation(g.Emoji) == emoji {
+			return g, true
+		}
+	}
+	return nil, false
+}
+
+// stripVariation removes emoji presentation selector, some editors and
+// terminals add or remove it, so emoji are compared without it
+func stripVariation(emoji string) string {
+	return strings.ReplaceAll(emoji, "\uFE0F", "")
+}
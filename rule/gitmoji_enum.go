@@ -0,0 +1,192 @@
+package rule
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/zexot-com/commitlint/lint"
+)
+
+var _ lint.SchemaRule = (*GitmojiEnumRule)(nil)
+
+// Gitmoji format constants
+const (
+	gitmojiFormatAny       = "any"
+	gitmojiFormatShortcode = "shortcode"
+	gitmojiFormatUnicode   = "unicode"
+)
+
+// GitmojiEnumRule to validate gitmoji before header
+type GitmojiEnumRule struct {
+	Gitmojis []string
+
+	AllowEmpty bool
+	CheckType  bool
+	Format     string
+
+	// types is gitmoji shortcode to conventional type
+	types map[string]string
+}
+
+// Name return name of the rule
+func (r *GitmojiEnumRule) Name() string { return "gitmoji-enum" }
+
+// Apply sets the needed argument for the rule
+func (r *GitmojiEnumRule) Apply(setting lint.RuleSetting) error {
+	r.Gitmojis = nil
+	r.AllowEmpty = false
+	r.CheckType = false
+	r.Format = gitmojiFormatAny
+
+	r.types = make(map[string]string)
+	for _, g := range knownGitmojis() {
+		r.types[g.Shortcode] = g.Type
+	}
+
+	if setting.Argument != nil {
+		var allowed []string
+		err := setStringArrArg(&allowed, setting.Argument)
+		if err != nil {
+			return errInvalidArg(r.Name(), err)
+		}
+		for _, a := range allowed {
+			g, ok := findGitmoji(a)
+			if !ok {
+				return errInvalidArg(r.Name(), fmt.Errorf("unknown gitmoji '%s'", a))
+			}
+			r.Gitmojis = append(r.Gitmojis, g.Shortcode)
+		}
+		// sorting the string elements for binary search
+		sort.Strings(r.Gitmojis)
+	}
+
+	if allowEmpty, ok := setting.Flags["allow-empty"]; ok {
+		err := setBoolArg(&r.AllowEmpty, allowEmpty)
+		if err != nil {
+			return errInvalidFlag(r.Name(), "allow-empty", err)
+		}
+	}
+
+	if checkType, ok := setting.Flags["check-type"]; ok {
+		err := setBoolArg(&r.CheckType, checkType)
+		if err != nil {
+			return errInvalidFlag(r.Name(), "check-type", err)
+		}
+	}
+
+	if format, ok := setting.Flags["format"]; ok {
+		err := setStringArg(&r.Format, format)
+		if err != nil {
+			return errInvalidFlag(r.Name(), "format", err)
+		}
+		switch r.Format {
+		case gitmojiFormatAny, gitmojiFormatShortcode, gitmojiFormatUnicode:
+		default:
+			err := fmt.Errorf("unknown format '%s', should be one of [%s %s %s]", r.Format, gitmojiFormatAny, gitmojiFormatShortcode, gitmojiFormatUnicode)
+			return errInvalidFlag(r.Name(), "format", err)
+		}
+	}
+
+	if types, ok := setting.Flags["types"]; ok {
+		typeMap, ok := types.(map[interface{}]interface{})
+		if !ok {
+			return errInvalidFlag(r.Name(), "types", fmt.Errorf("expected map, got %T", types))
+		}
+		for k, v := range typeMap {
+			emoji, err := toString(k)
+			if err != nil {
+				return errInvalidFlag(r.Name(), "types", err)
+			}
+			typ, err := toString(v)
+			if err != nil {
+				return errInvalidFlag(r.Name(), "types", err)
+			}
+			g, ok := findGitmoji(emoji)
+			if !ok {
+				return errInvalidFlag(r.Name(), "types", fmt.Errorf("unknown gitmoji '%s'", emoji))
+			}
+			r.types[g.Shortcode] = typ
+		}
+	}
+	return nil
+}
+
+// ArgumentSchema returns the schema of allowed gitmojis, all known gitmojis if empty
+func (r *GitmojiEnumRule) ArgumentSchema() lint.Schema { return nullable(stringArrSchema()) }
+
+// FlagsSchema returns the schema of rule flags
+func (r *GitmojiEnumRule) FlagsSchema() map[string]lint.Schema {
+	return map[string]lint.Schema{
+		"allow-empty": boolSchema(),
+		"check-type":  boolSchema(),
+		"format":      enumSchema(gitmojiFormatAny, gitmojiFormatShortcode, gitmojiFormatUnicode),
+		"types":       stringMapSchema(),
+	}
+}
+
+// Validate validates GitmojiEnumRule
+func (r *GitmojiEnumRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
+	emoji := lint.CommitEmoji(msg)
+	if emoji == "" {
+		if r.AllowEmpty {
+			return nil, true
+		}
+		desc := "header should start with a gitmoji, like ':sparkles:' or '✨'"
+		return lint.NewIssue(desc), false
+	}
+
+	g, ok := findGitmoji(emoji)
+	if !ok {
+		desc := fmt.Sprintf("gitmoji '%s' is not known", emoji)
+		if !strings.HasPrefix(emoji, ":") {
+			return lint.NewIssue(desc), false
+		}
+		suggestions := closestWords(emoji, r.shortcodes(), 2)
+		if len(suggestions) == 0 {
+			return lint.NewIssue(desc), false
+		}
+		info := didYouMean(suggestions)
+		return lint.NewIssue(desc, info).WithSuggestions(suggestions...), false
+	}
+
+	if len(r.Gitmojis) > 0 && !search(r.Gitmojis, g.Shortcode) {
+		desc := fmt.Sprintf("gitmoji '%s' is not allowed, you can use one of %v", emoji, r.Gitmojis)
+		return lint.NewIssue(desc), false
+	}
+
+	if r.CheckType && msg.Type() != "" {
+		expectedType := r.types[g.Shortcode]
+		if expectedType != "" && expectedType != msg.Type() {
+			desc := fmt.Sprintf("gitmoji '%s' is for type '%s', but type is '%s'", emoji, expectedType, msg.Type())
+			return lint.NewIssue(desc), false
+		}
+	}
+
+	var expected string
+	switch r.Format {
+	case gitmojiFormatShortcode:
+		expected = g.Shortcode
+	case gitmojiFormatUnicode:
+		expected = g.Emoji
+	default:
+		return nil, true
+	}
+
+	if stripVariation(emoji) == stripVariation(expected) {
+		return nil, true
+	}
+
+	desc := fmt.Sprintf("gitmoji should be in %s format, use '%s' instead of '%s'", r.Format, expected, emoji)
+	fixed := expected + strings.TrimPrefix(msg.Message(), emoji)
+	return lint.NewIssue(desc).WithFix(fixed), false
+}
+
+func (r *GitmojiEnumRule) shortcodes() []string {
+	gitmojis := knownGitmojis()
+	codes := make([]string, 0, len(gitmojis))
+	for _, g := range gitmojis {
+		codes = append(codes, g.Shortcode)
+	}
+	return codes
+}
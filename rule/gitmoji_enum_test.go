@@ -0,0 +1,105 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/zexot-com/commitlint/lint"
+)
+
+func parseEmojiCommit(t *testing.T, msg string) lint.Commit {
+	t.Helper()
+
+	p, err := lint.NewParser(lint.ParserConfig{HeaderPattern: conventionalPattern, Emoji: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit, err := p.Parse(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return commit
+}
+
+func TestGitmojiEnum(t *testing.T) {
+	tests := []struct {
+		name    string
+		msg     string
+		arg     interface{}
+		flags   map[string]interface{}
+		isValid bool
+		desc    string
+		fix     string
+	}{
+		{name: "shortcode", msg: ":sparkles: feat: add login", isValid: true},
+		{name: "unicode", msg: "✨ feat: add login", isValid: true},
+		{name: "missing", msg: "feat: add login", desc: "header should start with a gitmoji, like ':sparkles:' or '✨'"},
+		{name: "missing allowed", msg: "feat: add login", flags: map[string]interface{}{"allow-empty": true}, isValid: true},
+		{name: "unknown", msg: ":sparkle: feat: add login", desc: "gitmoji ':sparkle:' is not known"},
+		{name: "not allowed", msg: ":memo: docs: add guide", arg: []interface{}{"✨", ":bug:"}, desc: "gitmoji ':memo:' is not allowed, you can use one of [:bug: :sparkles:]"},
+		{
+			name:  "type mismatch",
+			msg:   ":bug: feat: add login",
+			flags: map[string]interface{}{"check-type": true},
+			desc:  "gitmoji ':bug:' is for type 'fix', but type is 'feat'",
+		},
+		{
+			name:    "type override",
+			msg:     ":bug: hotfix: add login",
+			flags:   map[string]interface{}{"check-type": true, "types": map[interface{}]interface{}{":bug:": "hotfix"}},
+			isValid: true,
+		},
+		{
+			name:  "unicode format",
+			msg:   ":sparkles: feat: add login",
+			flags: map[string]interface{}{"format": "unicode"},
+			desc:  "gitmoji should be in unicode format, use '✨' instead of ':sparkles:'",
+			fix:   "✨ feat: add login",
+		},
+		{
+			name:  "shortcode format",
+			msg:   "✨ feat: add login",
+			flags: map[string]interface{}{"format": "shortcode"},
+			desc:  "gitmoji should be in shortcode format, use ':sparkles:' instead of '✨'",
+			fix:   ":sparkles: feat: add login",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &GitmojiEnumRule{}
+			applyRule(t, r, lint.RuleSetting{Argument: tc.arg, Flags: tc.flags})
+
+			issue, isValid := r.Validate(parseEmojiCommit(t, tc.msg))
+			if isValid != tc.isValid {
+				t.Fatalf("isValid = %v, want %v, issue %+v", isValid, tc.isValid, issue)
+			}
+			if tc.isValid {
+				return
+			}
+			if issue.Description() != tc.desc {
+				t.Errorf("desc = %q, want %q", issue.Description(), tc.desc)
+			}
+			if issue.Fix() != tc.fix {
+				t.Errorf("fix = %q, want %q", issue.Fix(), tc.fix)
+			}
+		})
+	}
+
+	err := (&GitmojiEnumRule{}).Apply(lint.RuleSetting{Flags: map[string]interface{}{"format": "emoji"}})
+	if err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestTypeEnumFixSkipsEmoji(t *testing.T) {
+	r := &TypeEnumRule{}
+	applyRule(t, r, lint.RuleSetting{Argument: []interface{}{"art", "feat"}})
+
+	issue, isValid := r.Validate(parseEmojiCommit(t, ":art: Art: format code"))
+	if isValid {
+		t.Fatal("expected issue for type case")
+	}
+	if want := ":art: art: format code"; issue.Fix() != want {
+		t.Errorf("fix = %q, want %q", issue.Fix(), want)
+	}
+}
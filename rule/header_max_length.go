@@ -2,26 +2,39 @@ package rule
 
 import "github.com/zexot-com/commitlint/lint"
 
-var _ lint.Rule = (*HeadMaxLenRule)(nil)
+var (
+	_ lint.PartialRule = (*HeadMaxLenRule)(nil)
+	_ lint.SchemaRule  = (*HeadMaxLenRule)(nil)
+)
 
 // HeadMaxLenRule to validate max length of header
 type HeadMaxLenRule struct {
 	CheckLen int
+	Unit     LengthUnit
 }
 
 // Name return name of the rule
 func (r *HeadMaxLenRule) Name() string { return "header-max-length" }
 
+// CanValidatePartial returns true as rule checks only the raw header
+func (r *HeadMaxLenRule) CanValidatePartial() bool { return true }
+
 // Apply sets the needed argument for the rule
 func (r *HeadMaxLenRule) Apply(setting lint.RuleSetting) error {
 	err := setIntArg(&r.CheckLen, setting.Argument)
 	if err != nil {
 		return errInvalidArg(r.Name(), err)
 	}
-	return nil
+	return setUnitFlag(r.Name(), &r.Unit, setting.Flags)
 }
 
+// ArgumentSchema returns the schema of length argument
+func (r *HeadMaxLenRule) ArgumentSchema() lint.Schema { return intSchema() }
+
+// FlagsSchema returns the schema of unit flag
+func (r *HeadMaxLenRule) FlagsSchema() map[string]lint.Schema { return unitFlagSchema() }
+
 // Validate validates HeadMaxLenRule
 func (r *HeadMaxLenRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
-	return validateMaxLen("header", r.CheckLen, msg.Header())
+	return validateMaxLen("header", r.Unit, r.CheckLen, msg.Header())
 }
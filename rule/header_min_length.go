@@ -4,26 +4,39 @@ import (
 	"github.com/zexot-com/commitlint/lint"
 )
 
-var _ lint.Rule = (*HeadMinLenRule)(nil)
+var (
+	_ lint.PartialRule = (*HeadMinLenRule)(nil)
+	_ lint.SchemaRule  = (*HeadMinLenRule)(nil)
+)
 
 // HeadMinLenRule to validate min length of header
 type HeadMinLenRule struct {
 	CheckLen int
+	Unit     LengthUnit
 }
 
 // Name return name of the rule
 func (r *HeadMinLenRule) Name() string { return "header-min-length" }
 
+// CanValidatePartial returns true as rule checks only the raw header
+func (r *HeadMinLenRule) CanValidatePartial() bool { return true }
+
 // Apply sets the needed argument for the rule
 func (r *HeadMinLenRule) Apply(setting lint.RuleSetting) error {
 	err := setIntArg(&r.CheckLen, setting.Argument)
 	if err != nil {
 		return errInvalidArg(r.Name(), err)
 	}
-	return nil
+	return setUnitFlag(r.Name(), &r.Unit, setting.Flags)
 }
 
+// ArgumentSchema returns the schema of length argument
+func (r *HeadMinLenRule) ArgumentSchema() lint.Schema { return intSchema() }
+
+// FlagsSchema returns the schema of unit flag
+func (r *HeadMinLenRule) FlagsSchema() map[string]lint.Schema { return unitFlagSchema() }
+
 // Validate validates HeadMinLenRule
 func (r *HeadMinLenRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
-	return validateMinLen("header", r.CheckLen, msg.Header())
+	return validateMinLen("header", r.Unit, r.CheckLen, msg.Header())
 }
@@ -0,0 +1,199 @@
+package rule
+
+import (
+	"fmt"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// LengthUnit represent the unit used to measure length in length rules
+type LengthUnit string
+
+// Length Unit Constants
+const (
+	// UnitBytes counts bytes of UTF-8 encoded text, default unit
+	UnitBytes LengthUnit = "bytes"
+
+	// UnitRunes counts unicode code points
+	UnitRunes LengthUnit = "runes"
+
+	// UnitGraphemes counts user perceived characters, like emoji with modifiers
+	UnitGraphemes LengthUnit = "graphemes"
+
+	// UnitColumns counts display columns, East Asian wide chars and emoji take 2 columns
+	UnitColumns LengthUnit = "columns"
+)
+
+const unitFlag = "unit"
+
+// setUnitFlag sets the length unit from 'unit' flag, defaults to bytes
+func setUnitFlag(ruleName string, retVal *LengthUnit, flags map[string]interface{}) error {
+	*retVal = UnitBytes
+
+	unitVal, ok := flags[unitFlag]
+	if !ok {
+		return nil
+	}
+
+	var unit string
+	err := setStringArg(&unit, unitVal)
+	if err != nil {
+		return errInvalidFlag(ruleName, unitFlag, err)
+	}
+
+	switch LengthUnit(unit) {
+	case UnitBytes, UnitRunes, UnitGraphemes, UnitColumns:
+		*retVal = LengthUnit(unit)
+		return nil
+	default:
+		err := fmt.Errorf("unknown unit '%s', should be one of [%s %s %s %s]", unit, UnitBytes, UnitRunes, UnitGraphemes, UnitColumns)
+		return errInvalidFlag(ruleName, unitFlag, err)
+	}
+}
+
+// measure returns the length of s in given unit
+func measure(unit LengthUnit, s string) int {
+	switch unit {
+	case UnitRunes:
+		return utf8.RuneCountInString(s)
+	case UnitGraphemes:
+		count := 0
+		forEachGrapheme(s, func(cluster []rune) { count++ })
+		return count
+	case UnitColumns:
+		width := 0
+		forEachGrapheme(s, func(cluster []rune) { width += clusterWidth(cluster) })
+		return width
+	default:
+		return len(s)
+	}
+}
+
+// forEachGrapheme calls fn for each grapheme cluster in s. It follows the
+// common extended grapheme cluster rules for combining marks, joiners,
+// variation selectors, emoji modifiers, regional indicators and CRLF
+func forEachGrapheme(s string, fn func(cluster []rune)) {
+	var cluster []rune
+	var prev rune
+
+	for _, ch := range s {
+		if len(cluster) > 0 && !isGraphemeBreak(cluster, prev, ch) {
+			cluster = append(cluster, ch)
+			prev = ch
+			continue
+		}
+
+		if len(cluster) > 0 {
+			fn(cluster)
+		}
+		cluster = []rune{ch}
+		prev = ch
+	}
+
+	if len(cluster) > 0 {
+		fn(cluster)
+	}
+}
+
+func isGraphemeBreak(cluster []rune, prev, ch rune) bool {
+	switch {
+	case prev == '\r' && ch == '\n':
+		return false
+	case prev == zeroWidthJoiner:
+		return false
+	case isExtend(ch):
+		return false
+	case isRegionalIndicator(prev) && isRegionalIndicator(ch):
+		// flags are pairs of regional indicators
+		return countRegionalIndicators(cluster)%2 == 0
+	case isHangulJamoMedial(ch):
+		return false
+	}
+	return true
+}
+
+const zeroWidthJoiner = 0x200D
+
+// isExtend checks if ch extends the previous grapheme cluster
+func isExtend(ch rune) bool {
+	return ch == zeroWidthJoiner ||
+		unicode.In(ch, unicode.Mn, unicode.Me, unicode.Mc) ||
+		(ch >= 0xFE00 && ch <= 0xFE0F) || // variation selectors
+		(ch >= 0xE0100 && ch <= 0xE01EF) || // variation selectors supplement
+		(ch >= 0x1F3FB && ch <= 0x1F3FF) || // emoji skin tone modifiers
+		(ch >= 0xE0020 && ch <= 0xE007F) // emoji tag sequences
+}
+
+func isRegionalIndicator(ch rune) bool {
+	return ch >= 0x1F1E6 && ch <= 0x1F1FF
+}
+
+func countRegionalIndicators(cluster []rune) int {
+	count := 0
+	for _, ch := range cluster {
+		if isRegionalIndicator(ch) {
+			count++
+		}
+	}
+	return count
+}
+
+// isHangulJamoMedial checks for hangul vowel and trailing consonant jamo
+// which combine with leading consonant into a syllable
+func isHangulJamoMedial(ch rune) bool {
+	return ch >= 0x1160 && ch <= 0x11FF
+}
+
+// clusterWidth returns display columns used by a grapheme cluster
+func clusterWidth(cluster []rune) int {
+	base := cluster[0]
+	if unicode.IsControl(base) || isExtend(base) {
+		return 0
+	}
+
+	// emoji presentation selector and emoji sequences are wide
+	for _, ch := range cluster[1:] {
+		if ch == 0xFE0F || ch == zeroWidthJoiner || (ch >= 0x1F3FB && ch <= 0x1F3FF) {
+			return 2
+		}
+	}
+
+	if isRegionalIndicator(base) || isWide(base) {
+		return 2
+	}
+	return 1
+}
+
+// wideRanges are East Asian Wide, Fullwidth and emoji presentation ranges
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F}, {0x231A, 0x231B}, {0x2329, 0x232A}, {0x23E9, 0x23EC},
+	{0x23F0, 0x23F0}, {0x23F3, 0x23F3}, {0x25FD, 0x25FE}, {0x2614, 0x2615},
+	{0x2648, 0x2653}, {0x267F, 0x267F}, {0x2693, 0x2693}, {0x26A1, 0x26A1},
+	{0x26AA, 0x26AB}, {0x26BD, 0x26BE}, {0x26C4, 0x26C5}, {0x26CE, 0x26CE},
+	{0x26D4, 0x26D4}, {0x26EA, 0x26EA}, {0x26F2, 0x26F3}, {0x26F5, 0x26F5},
+	{0x26FA, 0x26FA}, {0x26FD, 0x26FD}, {0x2705, 0x2705}, {0x270A, 0x270B},
+	{0x2728, 0x2728}, {0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755},
+	{0x2757, 0x2757}, {0x2795, 0x2797}, {0x27B0, 0x27B0}, {0x27BF, 0x27BF},
+	{0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55}, {0x2E80, 0x303E},
+	{0x3041, 0x33FF}, {0x3400, 0x4DBF}, {0x4E00, 0x9FFF}, {0xA000, 0xA4CF},
+	{0xA960, 0xA97F}, {0xAC00, 0xD7A3}, {0xF900, 0xFAFF}, {0xFE10, 0xFE19},
+	{0xFE30, 0xFE6F}, {0xFF00, 0xFF60}, {0xFFE0, 0xFFE6}, {0x16FE0, 0x16FE4},
+	{0x17000, 0x18AFF}, {0x1B000, 0x1B2FF}, {0x1F004, 0x1F004}, {0x1F0CF, 0x1F0CF},
+	{0x1F18E, 0x1F18E}, {0x1F191, 0x1F19A}, {0x1F200, 0x1F251}, {0x1F300, 0x1F320},
+	{0x1F32D, 0x1F335}, {0x1F337, 0x1F37C}, {0x1F37E, 0x1F393}, {0x1F3A0, 0x1F3CA},
+	{0x1F3CF, 0x1F3D3}, {0x1F3E0, 0x1F3F0}, {0x1F3F4, 0x1F3F4}, {0x1F3F8, 0x1F43E},
+	{0x1F440, 0x1F440}, {0x1F442, 0x1F4FC}, {0x1F4FF, 0x1F53D}, {0x1F54B, 0x1F54E},
+	{0x1F550, 0x1F567}, {0x1F57A, 0x1F57A}, {0x1F595, 0x1F596}, {0x1F5A4, 0x1F5A4},
+	{0x1F5FB, 0x1F64F}, {0x1F680, 0x1F6C5}, {0x1F6CC, 0x1F6CC}, {0x1F6D0, 0x1F6D2},
+	{0x1F6D5, 0x1F6D7}, {0x1F6DC, 0x1F6DF}, {0x1F6EB, 0x1F6EC}, {0x1F6F4, 0x1F6FC},
+	{0x1F7E0, 0x1F7EB}, {0x1F7F0, 0x1F7F0}, {0x1F90C, 0x1F93A}, {0x1F93C, 0x1F945},
+	{0x1F947, 0x1F9FF}, {0x1FA70, 0x1FAFF}, {0x20000, 0x2FFFD}, {0x30000, 0x3FFFD},
+}
+
+func isWide(ch rune) bool {
+	ind := sort.Search(len(wideRanges), func(i int) bool {
+		return wideRanges[i][1] >= ch
+	})
+	return ind < len(wideRanges) && wideRanges[ind][0] <= ch
+}
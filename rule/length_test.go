@@ -0,0 +1,34 @@
+package rule
+
+import "testing"
+
+func TestMeasure(t *testing.T) {
+	tests := []struct {
+		input string
+		unit  LengthUnit
+		want  int
+	}{
+		{"fix: typo", UnitBytes, 9},
+		{"Größe", UnitBytes, 7},
+		{"Größe", UnitRunes, 5},
+		{"Größe", UnitColumns, 5},
+		{"日本語", UnitRunes, 3},
+		{"日本語", UnitColumns, 6},
+		{"é", UnitRunes, 2},
+		{"é", UnitGraphemes, 1},
+		{"👍🏽", UnitRunes, 2},
+		{"👍🏽", UnitGraphemes, 1},
+		{"👍🏽", UnitColumns, 2},
+		{"👨‍👩‍👧", UnitGraphemes, 1},
+		{"🇩🇪🇯🇵", UnitGraphemes, 2},
+		{"🇩🇪🇯🇵", UnitColumns, 4},
+		{"✨ feat", UnitColumns, 7},
+	}
+
+	for _, test := range tests {
+		got := measure(test.unit, test.input)
+		if got != test.want {
+			t.Errorf("measure(%s, %q) = %d, want %d", test.unit, test.input, got, test.want)
+		}
+	}
+}
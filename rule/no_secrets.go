@@ -0,0 +1,241 @@
+package rule
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/zexot-com/commitlint/lint"
+)
+
+var (
+	_ lint.PartialRule = (*NoSecretsRule)(nil)
+	_ lint.SchemaRule  = (*NoSecretsRule)(nil)
+)
+
+// secretPattern represent a known credential format
+// if pattern has a 'secret' group, only the group is treated as secret
+type secretPattern struct {
+	Kind    string
+	Pattern *regexp.Regexp
+}
+
+var knownSecretPatterns = []*secretPattern{
+	{"aws access key", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"google api key", regexp.MustCompile(`\bAIza[0-9A-Za-z_\-]{35}\b`)},
+	{"github token", regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`)},
+	{"slack token", regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9\-]{10,}`)},
+	{"private key", regexp.MustCompile(`-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY-----`)},
+	{"jwt", regexp.MustCompile(`\beyJ[A-Za-z0-9_\-]{5,}\.eyJ[A-Za-z0-9_\-]{5,}\.[A-Za-z0-9_\-]{10,}`)},
+	{"connection string password", regexp.MustCompile(`\b[A-Za-z][A-Za-z0-9+.\-]*://[^\s:/@]+:(?P<secret>[^\s@/]+)@[^\s]+`)},
+	// assignment with '=' or quoted value, like password="hunter2"
+	{"password assignment", regexp.MustCompile(`(?i)\b` + secretKeys + `\s*(?:=\s*['"]?|:\s*['"])(?P<secret>[^\s'"]{4,})`)},
+	// unquoted value after ':' should have a digit or symbol, to skip prose like 'Password: must be set'
+	{"password assignment", regexp.MustCompile(`(?i)\b` + secretKeys + `\s*:\s*(?P<secret>[^\s'"]*[^\sA-Za-z'".,;:!?()][^\s'"]*)`)},
+}
+
+// secretKeys matches the names of secret values in assignments
+const secretKeys = `(?:password|passwd|pwd|secret|api[_\-]?key|access[_\-]?token)`
+
+// minSecretLen is the min length of secret found by patterns
+const minSecretLen = 4
+
+// entropyCandidateRe matches tokens which can be random strings, '/' is
+// excluded so that paths are checked by parts
+var entropyCandidateRe = regexp.MustCompile(`[A-Za-z0-9+=_\-]+`)
+
+const (
+	defaultEntropyThreshold = 4.0
+	defaultEntropyMinLen    = 20
+)
+
+// NoSecretsRule to validate commit message does not contain secrets
+type NoSecretsRule struct {
+	Patterns  []*secretPattern
+	Allowlist []*regexp.Regexp
+
+	Entropy       float64
+	EntropyMinLen int
+}
+
+// Name return name of the rule
+func (r *NoSecretsRule) Name() string { return "no-secrets" }
+
+// CanValidatePartial returns true, secrets in raw header and body are reported even if header is invalid
+func (r *NoSecretsRule) CanValidatePartial() bool { return true }
+
+// Apply sets the needed argument for the rule
+func (r *NoSecretsRule) Apply(setting lint.RuleSetting) error {
+	r.Patterns = knownSecretPatterns
+	r.Allowlist = nil
+	r.Entropy = defaultEntropyThreshold
+	r.EntropyMinLen = defaultEntropyMinLen
+
+	if setting.Argument != nil {
+		var userPatterns []string
+		err := setStringArrArg(&userPatterns, setting.Argument)
+		if err != nil {
+			return errInvalidArg(r.Name(), err)
+		}
+
+		// copy to keep known patterns unchanged
+		r.Patterns = append([]*secretPattern{}, knownSecretPatterns...)
+		for _, p := range userPatterns {
+			re, err := regexp.Compile(p)
+			if err != nil {
+				return errInvalidArg(r.Name(), err)
+			}
+			r.Patterns = append(r.Patterns, &secretPattern{Kind: "custom pattern", Pattern: re})
+		}
+	}
+
+	if allowlist, ok := setting.Flags["allowlist"]; ok {
+		var allowed []string
+		err := setStringArrArg(&allowed, allowlist)
+		if err != nil {
+			return errInvalidFlag(r.Name(), "allowlist", err)
+		}
+		for _, a := range allowed {
+			re, err := regexp.Compile(a)
+			if err != nil {
+				return errInvalidFlag(r.Name(), "allowlist", err)
+			}
+			r.Allowlist = append(r.Allowlist, re)
+		}
+	}
+
+	if entropy, ok := setting.Flags["entropy"]; ok {
+		err := setFloatArg(&r.Entropy, entropy)
+		if err != nil {
+			return errInvalidFlag(r.Name(), "entropy", err)
+		}
+	}
+
+	if minLen, ok := setting.Flags["entropy-min-length"]; ok {
+		err := setIntArg(&r.EntropyMinLen, minLen)
+		if err != nil {
+			return errInvalidFlag(r.Name(), "entropy-min-length", err)
+		}
+	}
+	return nil
+}
+
+// ArgumentSchema returns the schema of custom secret patterns
+func (r *NoSecretsRule) ArgumentSchema() lint.Schema { return nullable(stringArrSchema()) }
+
+// FlagsSchema returns the schema of rule flags
+func (r *NoSecretsRule) FlagsSchema() map[string]lint.Schema {
+	return map[string]lint.Schema{
+		"allowlist":          stringArrSchema(),
+		"entropy":            numberSchema(),
+		"entropy-min-length": intSchema(),
+	}
+}
+
+// Validate validates NoSecretsRule
+func (r *NoSecretsRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
+	var infos []string
+	var secrets []string
+
+	for index, line := range strings.Split(msg.Message(), "\n") {
+		found := make(map[string]struct{})
+
+		for _, p := range r.Patterns {
+			for _, match := range p.Pattern.FindAllStringSubmatch(line, -1) {
+				secret := secretFromMatch(p.Pattern, match)
+				if len(secret) < minSecretLen {
+					continue
+				}
+				if _, ok := found[secret]; ok || r.isAllowed(secret) {
+					continue
+				}
+				found[secret] = struct{}{}
+				secrets = append(secrets, secret)
+				infos = append(infos, fmt.Sprintf("line %d: %s '%s'", index+1, p.Kind, lint.Redact(secret)))
+			}
+		}
+
+		// negative entropy threshold disables entropy check
+		if r.Entropy < 0 {
+			continue
+		}
+
+		// links like issue or commit urls are not secrets, secrets in
+		// urls are found by connection string pattern
+		for _, candidate := range entropyCandidateRe.FindAllString(urlRe.ReplaceAllString(line, " "), -1) {
+			if len(candidate) < r.EntropyMinLen || !isRandomLike(candidate) {
+				continue
+			}
+			if shannonEntropy(candidate) < r.Entropy {
+				continue
+			}
+			if isFound(found, candidate) || r.isAllowed(candidate) {
+				continue
+			}
+			found[candidate] = struct{}{}
+			secrets = append(secrets, candidate)
+			infos = append(infos, fmt.Sprintf("line %d: high entropy string '%s'", index+1, lint.Redact(candidate)))
+		}
+	}
+
+	if len(secrets) == 0 {
+		return nil, true
+	}
+
+	desc := "commit message should not contain secrets or sensitive data"
+	return lint.NewIssue(desc, infos...).WithRedactions(secrets...), false
+}
+
+func (r *NoSecretsRule) isAllowed(secret string) bool {
+	for _, re := range r.Allowlist {
+		if re.MatchString(secret) {
+			return true
+		}
+	}
+	return false
+}
+
+func secretFromMatch(re *regexp.Regexp, match []string) string {
+	ind := re.SubexpIndex("secret")
+	if ind > 0 && match[ind] != "" {
+		return match[ind]
+	}
+	return match[0]
+}
+
+// isFound checks if candidate is already reported as part of other secret
+func isFound(found map[string]struct{}, candidate string) bool {
+	for secret := range found {
+		if strings.Contains(secret, candidate) || strings.Contains(candidate, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRandomLike checks if s has both letters and digits
+// to skip long identifiers and words which have high entropy too
+func isRandomLike(s string) bool {
+	hasLetter := strings.ContainsFunc(s, unicode.IsLetter)
+	hasDigit := strings.ContainsFunc(s, unicode.IsDigit)
+	return hasLetter && hasDigit
+}
+
+// shannonEntropy returns the entropy in bits per char of given string
+func shannonEntropy(s string) float64 {
+	freq := make(map[rune]int)
+	total := 0
+	for _, ch := range s {
+		freq[ch]++
+		total++
+	}
+
+	entropy := 0.0
+	for _, count := range freq {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
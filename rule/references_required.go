@@ -0,0 +1,237 @@
+package rule
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/zexot-com/commitlint/lint"
+)
+
+var (
+	_ lint.PartialRule     = (*ReferencesRequiredRule)(nil)
+	_ lint.SchemaRule      = (*ReferencesRequiredRule)(nil)
+	_ lint.EnvironmentRule = (*ReferencesRequiredRule)(nil)
+)
+
+// reference locations
+const (
+	refInDescription = "description"
+	refInBody        = "body"
+	refInFooter      = "footer"
+)
+
+const defaultRefPattern = `[A-Z][A-Z0-9]+-[0-9]+`
+
+// ReferencesRequiredRule to validate that commit references an issue tracker ticket
+type ReferencesRequiredRule struct {
+	Pattern *regexp.Regexp
+
+	Locations    []string
+	FooterTokens []string
+	Projects     []string
+
+	BranchFallback bool
+
+	// branch is the current branch used by branch fallback, set only
+	// when linting a new commit message
+	branch string
+}
+
+// Name return name of the rule
+func (r *ReferencesRequiredRule) Name() string { return "references-required" }
+
+// CanValidatePartial returns true if rule does not check description
+func (r *ReferencesRequiredRule) CanValidatePartial() bool {
+	for _, loc := range r.Locations {
+		if loc == refInDescription {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply sets the needed argument for the rule
+func (r *ReferencesRequiredRule) Apply(setting lint.RuleSetting) error {
+	r.Pattern = regexp.MustCompile(defaultRefPattern)
+	r.Locations = []string{refInDescription, refInFooter}
+	r.FooterTokens = []string{"Refs"}
+	r.Projects = nil
+	r.BranchFallback = false
+
+	if setting.Argument != nil {
+		err := setRegexArg(&r.Pattern, setting.Argument)
+		if err != nil {
+			return errInvalidArg(r.Name(), err)
+		}
+	}
+
+	if locations, ok := setting.Flags["locations"]; ok {
+		err := setStringArrArg(&r.Locations, locations)
+		if err != nil {
+			return errInvalidFlag(r.Name(), "locations", err)
+		}
+		for _, loc := range r.Locations {
+			if loc != refInDescription && loc != refInBody && loc != refInFooter {
+				return errInvalidFlag(r.Name(), "locations", fmt.Errorf("unknown location '%s'", loc))
+			}
+		}
+		if len(r.Locations) == 0 {
+			return errNeedAtleastOneArg(r.Name(), "locations")
+		}
+	}
+
+	if tokens, ok := setting.Flags["footer-tokens"]; ok {
+		err := setStringArrArg(&r.FooterTokens, tokens)
+		if err != nil {
+			return errInvalidFlag(r.Name(), "footer-tokens", err)
+		}
+	}
+
+	if projects, ok := setting.Flags["projects"]; ok {
+		err := setStringArrArg(&r.Projects, projects)
+		if err != nil {
+			return errInvalidFlag(r.Name(), "projects", err)
+		}
+	}
+
+	if fallback, ok := setting.Flags["branch-fallback"]; ok {
+		err := setBoolArg(&r.BranchFallback, fallback)
+		if err != nil {
+			return errInvalidFlag(r.Name(), "branch-fallback", err)
+		}
+	}
+
+	// sorting the string elements for binary search
+	sort.Strings(r.Projects)
+	return nil
+}
+
+// ArgumentSchema returns the schema of reference pattern
+func (r *ReferencesRequiredRule) ArgumentSchema() lint.Schema { return nullable(stringSchema()) }
+
+// FlagsSchema returns the schema of rule flags
+func (r *ReferencesRequiredRule) FlagsSchema() map[string]lint.Schema {
+	return map[string]lint.Schema{
+		"locations":       enumArrSchema(refInDescription, refInBody, refInFooter),
+		"footer-tokens":   stringArrSchema(),
+		"projects":        stringArrSchema(),
+		"branch-fallback": boolSchema(),
+	}
+}
+
+// SetEnvironment sets the current branch for branch fallback
+func (r *ReferencesRequiredRule) SetEnvironment(env lint.Environment) { r.branch = env.Branch }
+
+// Validate validates ReferencesRequiredRule
+func (r *ReferencesRequiredRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
+	refs := r.findRefs(msg)
+
+	var unknownRefs []string
+	for _, ref := range refs {
+		if r.isProjectAllowed(ref) {
+			return nil, true
+		}
+		unknownRefs = append(unknownRefs, ref)
+	}
+
+	if len(unknownRefs) > 0 {
+		desc := fmt.Sprintf("references should belong to one of projects %v", r.Projects)
+		info := fmt.Sprintf("[%s] references are not allowed", strings.Join(unknownRefs, ", "))
+		return lint.NewIssue(desc, info), false
+	}
+
+	desc := fmt.Sprintf("commit should reference an issue in %s", r.locationsDesc())
+	if !r.BranchFallback || r.branch == "" {
+		return lint.NewIssue(desc), false
+	}
+
+	ref := r.BranchReference(r.branch)
+	if ref == "" {
+		return lint.NewIssue(desc), false
+	}
+
+	footerLine := r.FooterToken() + ": " + ref
+	info := fmt.Sprintf("branch '%s' references '%s', add '%s' footer", r.branch, ref, footerLine)
+	return lint.NewIssue(desc, info).WithFix(appendFooter(msg, footerLine)), false
+}
+
+// BranchReference returns the first reference in branch name which belongs
+// to allowed projects, empty if branch has no reference
+func (r *ReferencesRequiredRule) BranchReference(branch string) string {
+	for _, ref := range r.Pattern.FindAllString(branch, -1) {
+		if r.isProjectAllowed(ref) {
+			return ref
+		}
+	}
+	return ""
+}
+
+func (r *ReferencesRequiredRule) findRefs(msg lint.Commit) []string {
+	var refs []string
+	for _, loc := range r.Locations {
+		switch loc {
+		case refInDescription:
+			refs = append(refs, r.Pattern.FindAllString(msg.Description(), -1)...)
+		case refInBody:
+			refs = append(refs, r.Pattern.FindAllString(msg.Body(), -1)...)
+		case refInFooter:
+			for _, note := range msg.Notes() {
+				if !r.isFooterToken(note.Token()) {
+					continue
+				}
+				refs = append(refs, r.Pattern.FindAllString(note.Value(), -1)...)
+			}
+		}
+	}
+	return refs
+}
+
+func (r *ReferencesRequiredRule) isFooterToken(token string) bool {
+	// all footer tokens are allowed if not configured
+	if len(r.FooterTokens) == 0 {
+		return true
+	}
+	for _, t := range r.FooterTokens {
+		if strings.EqualFold(t, token) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ReferencesRequiredRule) isProjectAllowed(ref string) bool {
+	if len(r.Projects) == 0 {
+		return true
+	}
+	return search(r.Projects, refProject(ref))
+}
+
+// FooterToken returns the footer token used to add reference
+func (r *ReferencesRequiredRule) FooterToken() string {
+	if len(r.FooterTokens) == 0 {
+		return "Refs"
+	}
+	return r.FooterTokens[0]
+}
+
+func (r *ReferencesRequiredRule) locationsDesc() string {
+	locs := make([]string, len(r.Locations))
+	for i, loc := range r.Locations {
+		if loc == refInFooter && len(r.FooterTokens) > 0 {
+			loc = fmt.Sprintf("footer [%s]", strings.Join(r.FooterTokens, ", "))
+		}
+		locs[i] = loc
+	}
+	return strings.Join(locs, " or ")
+}
+
+// refProject returns project key of the reference, PAY for PAY-1234
+func refProject(ref string) string {
+	ind := strings.LastIndex(ref, "-")
+	if ind < 0 {
+		return ref
+	}
+	return ref[:ind]
+}
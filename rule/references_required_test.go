@@ -0,0 +1,61 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/zexot-com/commitlint/lint"
+)
+
+func TestReferencesRequired(t *testing.T) {
+	tests := []struct {
+		name    string
+		msg     string
+		flags   map[string]interface{}
+		branch  string
+		isValid bool
+		fix     string
+	}{
+		{name: "description", msg: "feat: add login PAY-12", isValid: true},
+		{name: "footer", msg: "feat: add login\n\nRefs: PAY-12", isValid: true},
+		{name: "other footer token", msg: "feat: add login\n\nCloses: PAY-12"},
+		{name: "body not checked", msg: "feat: add login\n\nfor PAY-12"},
+		{name: "body", msg: "feat: add login\n\nfor PAY-12", flags: map[string]interface{}{"locations": []interface{}{"body"}}, isValid: true},
+		{name: "project", msg: "feat: add login PAY-12", flags: map[string]interface{}{"projects": []interface{}{"OPS"}}},
+		{name: "no fallback", msg: "feat: add login", branch: "feature/PAY-12-login"},
+		{
+			name:   "fallback without branch",
+			msg:    "feat: add login",
+			flags:  map[string]interface{}{"branch-fallback": true},
+			branch: "",
+		},
+		{
+			name:   "fallback",
+			msg:    "feat: add login",
+			flags:  map[string]interface{}{"branch-fallback": true},
+			branch: "feature/PAY-12-login",
+			fix:    "feat: add login\n\nRefs: PAY-12\n",
+		},
+		{
+			name:   "fallback branch without reference",
+			msg:    "feat: add login",
+			flags:  map[string]interface{}{"branch-fallback": true},
+			branch: "main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &ReferencesRequiredRule{}
+			applyRule(t, r, lint.RuleSetting{Flags: tc.flags})
+			r.SetEnvironment(lint.Environment{Branch: tc.branch})
+
+			issue, isValid := r.Validate(parseCommit(t, tc.msg))
+			if isValid != tc.isValid {
+				t.Fatalf("isValid = %v, want %v", isValid, tc.isValid)
+			}
+			if !isValid && issue.Fix() != tc.fix {
+				t.Errorf("fix = %q, want %q", issue.Fix(), tc.fix)
+			}
+		})
+	}
+}
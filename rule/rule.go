@@ -3,6 +3,7 @@ package rule
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -25,16 +26,16 @@ func errInvalidFlag(ruleName, flagName string, err error) error {
 	return fmt.Errorf("%s: invalid flag '%s': %v", ruleName, flagName, err)
 }
 
-func formMinLenMsg(typ string, actualLen, expectedLen int) string {
-	return fmt.Sprintf("%s length is %d, should have atleast %d chars", typ, actualLen, expectedLen)
+func formMinLenMsg(typ string, unit LengthUnit, actualLen, expectedLen int) string {
+	return fmt.Sprintf("%s length is %d %s, should have atleast %d %s", typ, actualLen, unit, expectedLen, unit)
 }
 
-func formMaxLenDesc(typ string, actualLen, expectedLen int) string {
-	return fmt.Sprintf("%s length is %d, should have less than %d chars", typ, actualLen, expectedLen)
+func formMaxLenDesc(typ string, unit LengthUnit, actualLen, expectedLen int) string {
+	return fmt.Sprintf("%s length is %d %s, should have less than %d %s", typ, actualLen, unit, expectedLen, unit)
 }
 
-func formMaxLineLenDesc(typ string, expectedLen int) string {
-	return fmt.Sprintf("each %s line should have less than %d chars", typ, expectedLen)
+func formMaxLineLenDesc(typ string, unit LengthUnit, expectedLen int) string {
+	return fmt.Sprintf("each %s line should have less than %d %s", typ, expectedLen, unit)
 }
 
 func search(arr []string, toFind string) bool {
@@ -65,37 +66,38 @@ func validateCharset(allowedCharset, toCheck string) (string, bool) {
 	return invalidRunes, false
 }
 
-func validateMinLen(typ string, expectedLen int, toCheck string) (*lint.Issue, bool) {
-	actualLen := len(toCheck)
+func validateMinLen(typ string, unit LengthUnit, expectedLen int, toCheck string) (*lint.Issue, bool) {
+	actualLen := measure(unit, toCheck)
 	if actualLen >= expectedLen {
 		return nil, true
 	}
 
-	desc := formMinLenMsg(typ, actualLen, expectedLen)
+	desc := formMinLenMsg(typ, unit, actualLen, expectedLen)
 	return lint.NewIssue(desc), false
 }
 
-func validateMaxLen(typ string, expectedLen int, toCheck string) (*lint.Issue, bool) {
+func validateMaxLen(typ string, unit LengthUnit, expectedLen int, toCheck string) (*lint.Issue, bool) {
 	if expectedLen < 0 {
 		return nil, true
 	}
 
-	if len(toCheck) <= expectedLen {
+	actualLen := measure(unit, toCheck)
+	if actualLen <= expectedLen {
 		return nil, true
 	}
 
-	desc := formMaxLenDesc(typ, len(toCheck), expectedLen)
+	desc := formMaxLenDesc(typ, unit, actualLen, expectedLen)
 	return lint.NewIssue(desc), false
 }
 
-func validateMaxLineLength(typ string, expectedLen int, toCheck string) (*lint.Issue, bool) {
+func validateMaxLineLength(typ string, unit LengthUnit, expectedLen int, toCheck string) (*lint.Issue, bool) {
 	lines := strings.Split(toCheck, "\n")
 
 	msgs := []string{}
 	for index, line := range lines {
-		actualLen := len(line)
+		actualLen := measure(unit, line)
 		if actualLen > expectedLen {
-			errMsg := fmt.Sprintf("in line %d, length is %d", index+1, actualLen)
+			errMsg := fmt.Sprintf("in line %d, length is %d %s", index+1, actualLen, unit)
 			msgs = append(msgs, errMsg)
 		}
 	}
@@ -104,10 +106,59 @@ func validateMaxLineLength(typ string, expectedLen int, toCheck string) (*lint.I
 		return nil, true
 	}
 
-	desc := formMaxLineLenDesc(typ, expectedLen)
+	desc := formMaxLineLenDesc(typ, unit, expectedLen)
 	return lint.NewIssue(desc, msgs...), false
 }
 
+// appendFooter returns the commit message with given footer line appended
+// to the existing footer, or as a new footer paragraph if there is none
+func appendFooter(msg lint.Commit, footerLine string) string {
+	commitMsg := strings.TrimRight(msg.Message(), "\n")
+	if msg.Footer() != "" {
+		return commitMsg + "\n" + footerLine + "\n"
+	}
+	return commitMsg + "\n\n" + footerLine + "\n"
+}
+
+// replaceLine returns the commit message with given line number replaced
+// line number starts from 1
+func replaceLine(commitMsg string, lineNo int, newLine string) string {
+	lines := strings.Split(commitMsg, "\n")
+	if lineNo < 1 || lineNo > len(lines) {
+		return commitMsg
+	}
+	lines[lineNo-1] = newLine
+	return strings.Join(lines, "\n")
+}
+
+// replaceInHeader returns the commit message with first occurrence of from
+// in header, at or after given byte offset, replaced with to
+func replaceInHeader(msg lint.Commit, offset int, from, to string) string {
+	header, _, _ := strings.Cut(msg.Message(), "\n")
+	if offset > len(header) {
+		return msg.Message()
+	}
+
+	ind := strings.Index(header[offset:], from)
+	if ind < 0 {
+		return msg.Message()
+	}
+	ind += offset
+
+	newHeader := header[:ind] + to + header[ind+len(from):]
+	return replaceLine(msg.Message(), 1, newHeader)
+}
+
+// lineOf returns the line number of first occurrence of sub in text
+// line number starts from 1
+func lineOf(text, sub string) int {
+	ind := strings.Index(text, sub)
+	if ind < 0 {
+		return 1
+	}
+	return strings.Count(text[:ind], "\n") + 1
+}
+
 func setBoolArg(retVal *bool, arg interface{}) error {
 	boolVal, err := toBool(arg)
 	if err != nil {
@@ -126,6 +177,15 @@ func setIntArg(retVal *int, arg interface{}) error {
 	return nil
 }
 
+func setFloatArg(retVal *float64, arg interface{}) error {
+	floatVal, err := toFloat(arg)
+	if err != nil {
+		return err
+	}
+	*retVal = floatVal
+	return nil
+}
+
 func setStringArg(retVal *string, arg interface{}) error {
 	strVal, err := toString(arg)
 	if err != nil {
@@ -144,6 +204,19 @@ func setStringArrArg(retVal *[]string, arg interface{}) error {
 	return nil
 }
 
+func setRegexArg(retVal **regexp.Regexp, arg interface{}) error {
+	strVal, err := toString(arg)
+	if err != nil {
+		return err
+	}
+	re, err := regexp.Compile(strVal)
+	if err != nil {
+		return err
+	}
+	*retVal = re
+	return nil
+}
+
 func toBool(arg interface{}) (bool, error) {
 	boolVal, ok := arg.(bool)
 	if !ok {
@@ -160,6 +233,17 @@ func toInt(arg interface{}) (int, error) {
 	return intVal, nil
 }
 
+func toFloat(arg interface{}) (float64, error) {
+	switch argVal := arg.(type) {
+	case float64:
+		return argVal, nil
+	case int:
+		return float64(argVal), nil
+	default:
+		return 0, fmt.Errorf("expects number value, but got %#v", arg)
+	}
+}
+
 func toString(arg interface{}) (string, error) {
 	strVal, ok := arg.(string)
 	if !ok {
@@ -0,0 +1,34 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/zexot-com/commitlint/lint"
+)
+
+// conventionalPattern parses header same as conventional parser, so that
+// rule tests do not depend on the parser module
+const conventionalPattern = `^(?P<type>[\w-]+)(?:\((?P<scope>[^()]*)\))?(?P<breaking>!)?: (?P<description>.+)$`
+
+func parseCommit(t *testing.T, msg string) lint.Commit {
+	t.Helper()
+
+	p, err := lint.NewRegexParser(conventionalPattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit, err := p.Parse(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return commit
+}
+
+func applyRule(t *testing.T, r lint.Rule, setting lint.RuleSetting) {
+	t.Helper()
+
+	err := r.Apply(setting)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
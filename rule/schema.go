@@ -0,0 +1,70 @@
+package rule
+
+import (
+	"sort"
+
+	"github.com/zexot-com/commitlint/lint"
+)
+
+func intSchema() lint.Schema { return lint.Schema{"type": "integer"} }
+
+func numberSchema() lint.Schema { return lint.Schema{"type": "number"} }
+
+func boolSchema() lint.Schema { return lint.Schema{"type": "boolean"} }
+
+func stringSchema() lint.Schema { return lint.Schema{"type": "string"} }
+
+func stringArrSchema() lint.Schema {
+	return lint.Schema{"type": "array", "items": stringSchema()}
+}
+
+func enumSchema(values ...string) lint.Schema {
+	return lint.Schema{"type": "string", "enum": values}
+}
+
+func enumArrSchema(values ...string) lint.Schema {
+	return lint.Schema{"type": "array", "items": enumSchema(values...)}
+}
+
+// stringMapSchema returns the schema of key-value object with string values
+func stringMapSchema() lint.Schema {
+	return lint.Schema{"type": "object", "additionalProperties": stringSchema()}
+}
+
+// paramsSchema returns the schema of array of key-value params
+// with given properties, all of them are required
+func paramsSchema(props map[string]lint.Schema) lint.Schema {
+	required := make([]string, 0, len(props))
+	for name := range props {
+		required = append(required, name)
+	}
+	sort.Strings(required)
+
+	return lint.Schema{
+		"type": "array",
+		"items": lint.Schema{
+			"type":                 "object",
+			"properties":           props,
+			"required":             required,
+			"additionalProperties": false,
+		},
+	}
+}
+
+// nullable returns copy of schema s which also accepts null, used for
+// optional arguments which have defaults
+func nullable(s lint.Schema) lint.Schema {
+	c := make(lint.Schema, len(s))
+	for k, v := range s {
+		c[k] = v
+	}
+	c["type"] = []string{s["type"].(string), "null"}
+	return c
+}
+
+// unitFlagSchema returns the flags schema of length rules
+func unitFlagSchema() map[string]lint.Schema {
+	return map[string]lint.Schema{
+		unitFlag: enumSchema(string(UnitBytes), string(UnitRunes), string(UnitGraphemes), string(UnitColumns)),
+	}
+}
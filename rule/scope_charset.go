@@ -4,7 +4,7 @@ import (
 	"github.com/zexot-com/commitlint/lint"
 )
 
-var _ lint.Rule = (*ScopeCharsetRule)(nil)
+var _ lint.SchemaRule = (*ScopeCharsetRule)(nil)
 
 // ScopeCharsetRule to validate max length of header
 type ScopeCharsetRule struct {
@@ -23,6 +23,12 @@ func (r *ScopeCharsetRule) Apply(setting lint.RuleSetting) error {
 	return nil
 }
 
+// ArgumentSchema returns the schema of allowed charset
+func (r *ScopeCharsetRule) ArgumentSchema() lint.Schema { return stringSchema() }
+
+// FlagsSchema returns nil as rule has no flags
+func (r *ScopeCharsetRule) FlagsSchema() map[string]lint.Schema { return nil }
+
 // Validate validates ScopeCharsetRule
 func (r *ScopeCharsetRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
 	invalidChars, isValid := validateCharset(r.Charset, msg.Scope())
@@ -3,11 +3,12 @@ package rule
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/zexot-com/commitlint/lint"
 )
 
-var _ lint.Rule = (*ScopeEnumRule)(nil)
+var _ lint.SchemaRule = (*ScopeEnumRule)(nil)
 
 // ScopeEnumRule to validate max length of header
 type ScopeEnumRule struct {
@@ -39,6 +40,16 @@ func (r *ScopeEnumRule) Apply(setting lint.RuleSetting) error {
 	return nil
 }
 
+// ArgumentSchema returns the schema of allowed scopes
+func (r *ScopeEnumRule) ArgumentSchema() lint.Schema { return stringArrSchema() }
+
+// FlagsSchema returns the schema of rule flags
+func (r *ScopeEnumRule) FlagsSchema() map[string]lint.Schema {
+	return map[string]lint.Schema{
+		"allow-empty": boolSchema(),
+	}
+}
+
 // Validate validates ScopeEnumRule
 func (r *ScopeEnumRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
 	if msg.Scope() == "" {
@@ -54,6 +65,20 @@ func (r *ScopeEnumRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
 		return nil, true
 	}
 
-	errMsg := fmt.Sprintf("scope '%s' is not allowed, you can use one of %v", msg.Scope(), r.Scopes)
-	return lint.NewIssue(errMsg), false
+	suggestions := enumSuggestions(msg.Scope(), r.Scopes)
+	if len(suggestions) == 0 {
+		errMsg := fmt.Sprintf("scope '%s' is not allowed, you can use one of %v", msg.Scope(), r.Scopes)
+		return lint.NewIssue(errMsg), false
+	}
+
+	errMsg := fmt.Sprintf("scope '%s' is not allowed, %s", msg.Scope(), didYouMean(suggestions))
+	issue := lint.NewIssue(errMsg, fmt.Sprintf("you can use one of %v", r.Scopes)).WithSuggestions(suggestions...)
+
+	// only case mismatch is fixed, typos can have more than one suggestion
+	if strings.EqualFold(suggestions[0], msg.Scope()) {
+		// scope is after type, skip emoji and type which can contain the scope
+		offset := len(lint.CommitEmoji(msg)) + len(msg.Type())
+		issue = issue.WithFix(replaceInHeader(msg, offset, msg.Scope(), suggestions[0]))
+	}
+	return issue, false
 }
@@ -2,11 +2,12 @@ package rule
 
 import "github.com/zexot-com/commitlint/lint"
 
-var _ lint.Rule = (*ScopeMaxLenRule)(nil)
+var _ lint.SchemaRule = (*ScopeMaxLenRule)(nil)
 
 // ScopeMaxLenRule to validate max length of type
 type ScopeMaxLenRule struct {
 	CheckLen int
+	Unit     LengthUnit
 }
 
 // Name return name of the rule
@@ -18,10 +19,16 @@ func (r *ScopeMaxLenRule) Apply(setting lint.RuleSetting) error {
 	if err != nil {
 		return errInvalidArg(r.Name(), err)
 	}
-	return nil
+	return setUnitFlag(r.Name(), &r.Unit, setting.Flags)
 }
 
+// ArgumentSchema returns the schema of length argument
+func (r *ScopeMaxLenRule) ArgumentSchema() lint.Schema { return intSchema() }
+
+// FlagsSchema returns the schema of unit flag
+func (r *ScopeMaxLenRule) FlagsSchema() map[string]lint.Schema { return unitFlagSchema() }
+
 // Validate validates ScopeMaxLenRule
 func (r *ScopeMaxLenRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
-	return validateMaxLen("scope", r.CheckLen, msg.Scope())
+	return validateMaxLen("scope", r.Unit, r.CheckLen, msg.Scope())
 }
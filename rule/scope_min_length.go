@@ -2,11 +2,12 @@ package rule
 
 import "github.com/zexot-com/commitlint/lint"
 
-var _ lint.Rule = (*ScopeMinLenRule)(nil)
+var _ lint.SchemaRule = (*ScopeMinLenRule)(nil)
 
 // ScopeMinLenRule to validate min length of scope
 type ScopeMinLenRule struct {
 	CheckLen int
+	Unit     LengthUnit
 }
 
 // Name return name of the rule
@@ -18,10 +19,16 @@ func (r *ScopeMinLenRule) Apply(setting lint.RuleSetting) error {
 	if err != nil {
 		return errInvalidArg(r.Name(), err)
 	}
-	return nil
+	return setUnitFlag(r.Name(), &r.Unit, setting.Flags)
 }
 
+// ArgumentSchema returns the schema of length argument
+func (r *ScopeMinLenRule) ArgumentSchema() lint.Schema { return intSchema() }
+
+// FlagsSchema returns the schema of unit flag
+func (r *ScopeMinLenRule) FlagsSchema() map[string]lint.Schema { return unitFlagSchema() }
+
 // Validate validates ScopeMinLenRule
 func (r *ScopeMinLenRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
-	return validateMinLen("scope", r.CheckLen, msg.Scope())
+	return validateMinLen("scope", r.Unit, r.CheckLen, msg.Scope())
 }
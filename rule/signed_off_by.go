@@ -0,0 +1,153 @@
+package rule
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/zexot-com/commitlint/lint"
+)
+
+var (
+	_ lint.PartialRule     = (*SignedOffByRule)(nil)
+	_ lint.SchemaRule      = (*SignedOffByRule)(nil)
+	_ lint.EnvironmentRule = (*SignedOffByRule)(nil)
+)
+
+const (
+	signedOffByToken  = "Signed-off-by"
+	coAuthoredByToken = "Co-authored-by"
+)
+
+// identityRe matches git identity in 'Name <email>' format
+var identityRe = regexp.MustCompile(`^([^<>]+?) <([^<>\s@]+@[^<>\s]+)>$`)
+
+// SignedOffByRule to validate Developer Certificate of Origin sign-off trailer
+type SignedOffByRule struct {
+	MatchIdentity bool
+	CoAuthoredBy  bool
+
+	// identity is the committer identity, set only when linting a new commit message
+	identity lint.Signature
+}
+
+// Name return name of the rule
+func (r *SignedOffByRule) Name() string { return "signed-off-by" }
+
+// CanValidatePartial returns true, sign-off is required even if header is invalid
+func (r *SignedOffByRule) CanValidatePartial() bool { return true }
+
+// Apply sets the needed argument for the rule
+func (r *SignedOffByRule) Apply(setting lint.RuleSetting) error {
+	r.MatchIdentity = false
+	r.CoAuthoredBy = true
+
+	if matchIdentity, ok := setting.Flags["match-identity"]; ok {
+		err := setBoolArg(&r.MatchIdentity, matchIdentity)
+		if err != nil {
+			return errInvalidFlag(r.Name(), "match-identity", err)
+		}
+	}
+
+	if coAuthoredBy, ok := setting.Flags["co-authored-by"]; ok {
+		err := setBoolArg(&r.CoAuthoredBy, coAuthoredBy)
+		if err != nil {
+			return errInvalidFlag(r.Name(), "co-authored-by", err)
+		}
+	}
+	return nil
+}
+
+// ArgumentSchema returns empty schema as rule has no argument, any argument is ignored
+func (r *SignedOffByRule) ArgumentSchema() lint.Schema { return lint.Schema{} }
+
+// FlagsSchema returns the schema of rule flags
+func (r *SignedOffByRule) FlagsSchema() map[string]lint.Schema {
+	return map[string]lint.Schema{
+		"match-identity": boolSchema(),
+		"co-authored-by": boolSchema(),
+	}
+}
+
+// SetEnvironment sets the committer identity used for sign-off of new commit
+func (r *SignedOffByRule) SetEnvironment(env lint.Environment) { r.identity = env.Identity }
+
+// Validate validates SignedOffByRule
+func (r *SignedOffByRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
+	var invalids []string
+	var signOffs []lint.Signature
+
+	for _, note := range msg.Notes() {
+		switch note.Token() {
+		case signedOffByToken:
+			sign, ok := parseIdentity(note.Value())
+			if !ok {
+				invalids = append(invalids, fmt.Sprintf("'%s: %s' should be in 'Name <email>' format", note.Token(), note.Value()))
+				continue
+			}
+			signOffs = append(signOffs, sign)
+		case coAuthoredByToken:
+			if !r.CoAuthoredBy {
+				continue
+			}
+			if _, ok := parseIdentity(note.Value()); !ok {
+				invalids = append(invalids, fmt.Sprintf("'%s: %s' should be in 'Name <email>' format", note.Token(), note.Value()))
+			}
+		}
+	}
+
+	identity, hasIdentity := r.commitIdentity(msg)
+
+	// a missing sign-off is reported along with invalid trailers, as
+	// malformed trailers like Co-authored-by are not a sign-off
+	if len(signOffs) == 0 {
+		desc := fmt.Sprintf("'%s' trailer is missing", signedOffByToken)
+		if !hasIdentity {
+			return lint.NewIssue(desc, invalids...), false
+		}
+		footerLine := fmt.Sprintf("%s: %s <%s>", signedOffByToken, identity.Name, identity.Email)
+		return lint.NewIssue(desc, invalids...).WithFix(appendFooter(msg, footerLine)), false
+	}
+
+	if r.MatchIdentity {
+		if !hasIdentity {
+			invalids = append(invalids, "identity to match sign-off is unknown, set git config user.name and user.email")
+		} else if !hasSignOff(signOffs, identity) {
+			invalids = append(invalids, fmt.Sprintf("'%s' should match '%s <%s>'", signedOffByToken, identity.Name, identity.Email))
+		}
+	}
+
+	if len(invalids) == 0 {
+		return nil, true
+	}
+
+	desc := "sign-off trailers are invalid"
+	return lint.NewIssue(desc, invalids...), false
+}
+
+// commitIdentity returns the author of commits from history, else the
+// committer identity of new commit
+func (r *SignedOffByRule) commitIdentity(msg lint.Commit) (lint.Signature, bool) {
+	identity := r.identity
+	if authored, ok := msg.(lint.AuthoredCommit); ok {
+		identity = authored.Author()
+	}
+	return identity, identity.Name != "" && identity.Email != ""
+}
+
+func parseIdentity(value string) (lint.Signature, bool) {
+	matches := identityRe.FindStringSubmatch(strings.TrimSpace(value))
+	if matches == nil {
+		return lint.Signature{}, false
+	}
+	return lint.Signature{Name: matches[1], Email: matches[2]}, true
+}
+
+func hasSignOff(signOffs []lint.Signature, identity lint.Signature) bool {
+	for _, sign := range signOffs {
+		if sign.Name == identity.Name && strings.EqualFold(sign.Email, identity.Email) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/zexot-com/commitlint/lint"
+)
+
+func TestSignedOffBy(t *testing.T) {
+	me := lint.Signature{Name: "Jane Doe", Email: "jane@example.com"}
+
+	tests := []struct {
+		name     string
+		msg      string
+		flags    map[string]interface{}
+		identity lint.Signature
+		author   *lint.Signature
+		isValid  bool
+		desc     string
+		infos    int
+		fix      string
+	}{
+		{name: "signed", msg: "feat: add login\n\nSigned-off-by: Jane Doe <jane@example.com>", isValid: true},
+		{name: "missing without identity", msg: "feat: add login", desc: "'Signed-off-by' trailer is missing"},
+		{
+			name:     "missing with identity",
+			msg:      "feat: add login",
+			identity: me,
+			desc:     "'Signed-off-by' trailer is missing",
+			fix:      "feat: add login\n\nSigned-off-by: Jane Doe <jane@example.com>\n",
+		},
+		{
+			name:     "missing with invalid co-author",
+			msg:      "feat: add login\n\nCo-authored-by: John",
+			identity: me,
+			desc:     "'Signed-off-by' trailer is missing",
+			infos:    1,
+			fix:      "feat: add login\n\nCo-authored-by: John\nSigned-off-by: Jane Doe <jane@example.com>\n",
+		},
+		{name: "invalid sign-off", msg: "feat: add login\n\nSigned-off-by: Jane", desc: "'Signed-off-by' trailer is missing", infos: 1},
+		{
+			name:  "invalid co-author",
+			msg:   "feat: add login\n\nCo-authored-by: John\nSigned-off-by: Jane Doe <jane@example.com>",
+			desc:  "sign-off trailers are invalid",
+			infos: 1,
+		},
+		{
+			name:    "co-author not checked",
+			msg:     "feat: add login\n\nCo-authored-by: John\nSigned-off-by: Jane Doe <jane@example.com>",
+			flags:   map[string]interface{}{"co-authored-by": false},
+			isValid: true,
+		},
+		{
+			name:     "match identity",
+			msg:      "feat: add login\n\nSigned-off-by: Jane Doe <JANE@example.com>",
+			flags:    map[string]interface{}{"match-identity": true},
+			identity: me,
+			isValid:  true,
+		},
+		{
+			name:     "identity mismatch",
+			msg:      "feat: add login\n\nSigned-off-by: John Roe <john@example.com>",
+			flags:    map[string]interface{}{"match-identity": true},
+			identity: me,
+			desc:     "sign-off trailers are invalid",
+			infos:    1,
+		},
+		{
+			name:    "unknown identity",
+			msg:     "feat: add login\n\nSigned-off-by: John Roe <john@example.com>",
+			flags:   map[string]interface{}{"match-identity": true},
+			desc:    "sign-off trailers are invalid",
+			infos:   1,
+			isValid: false,
+		},
+		{
+			name:     "author overrides identity",
+			msg:      "feat: add login\n\nSigned-off-by: John Roe <john@example.com>",
+			flags:    map[string]interface{}{"match-identity": true},
+			identity: me,
+			author:   &lint.Signature{Name: "John Roe", Email: "john@example.com"},
+			isValid:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &SignedOffByRule{}
+			applyRule(t, r, lint.RuleSetting{Flags: tc.flags})
+			r.SetEnvironment(lint.Environment{Identity: tc.identity})
+
+			msg := parseCommit(t, tc.msg)
+			if tc.author != nil {
+				msg = lint.WithAuthor(msg, *tc.author)
+			}
+
+			issue, isValid := r.Validate(msg)
+			if isValid != tc.isValid {
+				t.Fatalf("isValid = %v, want %v", isValid, tc.isValid)
+			}
+			if isValid {
+				return
+			}
+			if issue.Description() != tc.desc || len(issue.Infos()) != tc.infos || issue.Fix() != tc.fix {
+				t.Errorf("got issue %q %q fix %q, want %q with %d infos fix %q",
+					issue.Description(), issue.Infos(), issue.Fix(), tc.desc, tc.infos, tc.fix)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package rule
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/zexot-com/commitlint/lint"
+)
+
+var (
+	_ lint.PartialRule = (*SpellingRule)(nil)
+	_ lint.SchemaRule  = (*SpellingRule)(nil)
+)
+
+var (
+	codeSpanRe = regexp.MustCompile("`[^`]*`")
+	urlRe      = regexp.MustCompile(`\b[A-Za-z][A-Za-z0-9+.\-]*://\S+|\bwww\.\S+|\S+@\S+\.\S+`)
+)
+
+// minSpellLength is the min length of checked words, shorter words like
+// 'a', 'of' or 'id' are not in dictionary
+const minSpellLength = 3
+
+// wordSuffixes are stripped to check inflected forms of dictionary words
+// each suffix is replaced with the given replacement, like 'ies' to 'y'
+var wordSuffixes = [][2]string{
+	{"s", ""}, {"es", ""}, {"ies", "y"},
+	{"d", ""}, {"ed", ""}, {"ied", "y"},
+	{"ing", ""}, {"ing", "e"}, {"ly", ""}, {"er", ""}, {"ers", ""},
+}
+
+// SpellingRule to validate spelling of description and body
+type SpellingRule struct {
+	Locations []string
+
+	words map[string]struct{}
+}
+
+// Name return name of the rule
+func (r *SpellingRule) Name() string { return "spelling" }
+
+// CanValidatePartial returns true as description is skipped if header can not be parsed
+func (r *SpellingRule) CanValidatePartial() bool { return true }
+
+// Apply sets the needed argument for the rule
+func (r *SpellingRule) Apply(setting lint.RuleSetting) error {
+	r.Locations = []string{refInDescription, refInBody}
+	r.words = make(map[string]struct{})
+
+	if setting.Argument != nil {
+		err := setStringArrArg(&r.Locations, setting.Argument)
+		if err != nil {
+			return errInvalidArg(r.Name(), err)
+		}
+		for _, loc := range r.Locations {
+			if loc != refInDescription && loc != refInBody {
+				return errInvalidArg(r.Name(), fmt.Errorf("unknown location '%s'", loc))
+			}
+		}
+	}
+
+	if words, ok := setting.Flags["words"]; ok {
+		var projectWords []string
+		err := setStringArrArg(&projectWords, words)
+		if err != nil {
+			return errInvalidFlag(r.Name(), "words", err)
+		}
+		r.addWords(projectWords)
+	}
+
+	if wordFiles, ok := setting.Flags["word-files"]; ok {
+		var files []string
+		err := setStringArrArg(&files, wordFiles)
+		if err != nil {
+			return errInvalidFlag(r.Name(), "word-files", err)
+		}
+		for _, f := range files {
+			// relative to config file, like other paths in config
+			if !filepath.IsAbs(f) && setting.Dir != "" {
+				f = filepath.Join(setting.Dir, f)
+			}
+			content, err := os.ReadFile(filepath.Clean(f))
+			if err != nil {
+				return errInvalidFlag(r.Name(), "word-files", err)
+			}
+			r.addWords(strings.Fields(string(content)))
+		}
+	}
+	return nil
+}
+
+func (r *SpellingRule) addWords(words []string) {
+	for _, w := range words {
+		r.words[strings.ToLower(w)] = struct{}{}
+	}
+}
+
+// ArgumentSchema returns the schema of locations to check
+func (r *SpellingRule) ArgumentSchema() lint.Schema {
+	return nullable(enumArrSchema(refInDescription, refInBody))
+}
+
+// FlagsSchema returns the schema of rule flags
+func (r *SpellingRule) FlagsSchema() map[string]lint.Schema {
+	return map[string]lint.Schema{
+		"words":      stringArrSchema(),
+		"word-files": stringArrSchema(),
+	}
+}
+
+// Validate validates SpellingRule
+func (r *SpellingRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
+	var infos []string
+
+	for _, loc := range r.Locations {
+		switch loc {
+		case refInDescription:
+			infos = append(infos, r.checkText(msg.Description(), 1)...)
+		case refInBody:
+			if msg.Body() == "" {
+				continue
+			}
+			infos = append(infos, r.checkText(msg.Body(), lineOf(msg.Message(), msg.Body()))...)
+		}
+	}
+
+	if len(infos) == 0 {
+		return nil, true
+	}
+
+	desc := "misspelled words found"
+	return lint.NewIssue(desc, infos...), false
+}
+
+// checkText returns infos for misspelled words in text, startLine is the
+// line number of first line of text in commit message
+func (r *SpellingRule) checkText(text string, startLine int) []string {
+	_, dictList := dictionary()
+
+	var infos []string
+	inFence := false
+
+	for index, line := range strings.Split(text, "\n") {
+		// skip fenced and indented code blocks
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t") {
+			continue
+		}
+
+		line = codeSpanRe.ReplaceAllString(line, " ")
+		line = urlRe.ReplaceAllString(line, " ")
+
+		for _, word := range spellCheckWords(line) {
+			if r.isKnown(word) {
+				continue
+			}
+
+			info := fmt.Sprintf("line %d: '%s'", startLine+index, word)
+			suggestions := closestWords(strings.ToLower(word), dictList, 2)
+			if len(suggestions) > 0 {
+				info += ", " + didYouMean(suggestions)
+			}
+			infos = append(infos, info)
+		}
+	}
+	return infos
+}
+
+func (r *SpellingRule) isKnown(word string) bool {
+	dict, _ := dictionary()
+
+	lowerWord := strings.ToLower(word)
+	isWord := func(w string) bool {
+		_, inDict := dict[w]
+		_, inProject := r.words[w]
+		return inDict || inProject
+	}
+
+	if isWord(lowerWord) {
+		return true
+	}
+
+	// contractions like "don't" or "we're" are checked by the word before apostrophe
+	if base, _, ok := strings.Cut(lowerWord, "'"); ok {
+		return utf8.RuneCountInString(base) < minSpellLength || isWord(base)
+	}
+
+	for _, suffix := range wordSuffixes {
+		base, ok := strings.CutSuffix(lowerWord, suffix[0])
+		if ok && base != "" && isWord(base+suffix[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// spellCheckWords returns the words in line which should be spell checked
+// identifiers, paths, numbers, acronyms and short words are skipped
+func spellCheckWords(line string) []string {
+	var words []string
+	for _, token := range strings.Fields(line) {
+		token = strings.TrimFunc(token, func(ch rune) bool {
+			return !unicode.IsLetter(ch) && !unicode.IsDigit(ch)
+		})
+
+		if token == "" || strings.ContainsFunc(token, isIdentifierRune) {
+			continue
+		}
+
+		for _, word := range strings.Split(token, "-") {
+			if utf8.RuneCountInString(word) < minSpellLength || !isPlainWord(word) {
+				continue
+			}
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// isIdentifierRune checks for chars used in code identifiers and paths
+func isIdentifierRune(ch rune) bool {
+	return unicode.IsDigit(ch) || strings.ContainsRune(`/\_.=@#$%&*+|~:<>()[]{}`, ch)
+}
+
+// isPlainWord checks if word has only letters with optional apostrophe
+// and no upper case letters after first char like CamelCase or ACRONYM
+func isPlainWord(word string) bool {
+	for i, ch := range word {
+		if ch == '\'' {
+			continue
+		}
+		if !unicode.IsLetter(ch) {
+			return false
+		}
+		if i > 0 && unicode.IsUpper(ch) {
+			return false
+		}
+	}
+	return true
+}
+
+func quoteJoin(words []string) string {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		quoted[i] = "'" + w + "'"
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,90 @@
+package rule
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zexot-com/commitlint/lint"
+)
+
+func TestSpelling(t *testing.T) {
+	tests := []struct {
+		name    string
+		msg     string
+		arg     interface{}
+		flags   map[string]interface{}
+		isValid bool
+		infos   []string
+	}{
+		{name: "valid", msg: "fix: handle the empty config file\n\nThe parser returned an error, which wasn't reported.", isValid: true},
+		{name: "short words", msg: "fix: use a b c as ids in UI", isValid: true},
+		{name: "inflected words", msg: "docs: describes the fixed handlers", isValid: true},
+		{name: "description", msg: "fix: recieve the config", infos: []string{"line 1: 'recieve', did you mean 'receive', 'received', 'receiver'?"}},
+		{name: "body", msg: "fix: handle the config\n\nIt is now loded once.", infos: []string{"line 3: 'loded', did you mean 'loaded', 'coded', 'lived'?"}},
+		{
+			name:    "code is skipped",
+			msg:     "fix: use `recieve` in handleRecv\n\n    recieve := true\n\nsee https://example.com/recieve",
+			isValid: true,
+		},
+		{name: "body only", msg: "fix: recieve the config", arg: []interface{}{"body"}, isValid: true},
+		{
+			name:    "project words",
+			msg:     "feat: add kubectl plugin",
+			flags:   map[string]interface{}{"words": []interface{}{"Kubectl"}},
+			isValid: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &SpellingRule{}
+			applyRule(t, r, lint.RuleSetting{Argument: tc.arg, Flags: tc.flags})
+
+			issue, isValid := r.Validate(parseCommit(t, tc.msg))
+			if isValid != tc.isValid {
+				t.Fatalf("isValid = %v, want %v, issue %+v", isValid, tc.isValid, issue)
+			}
+			if tc.isValid {
+				return
+			}
+
+			infos := issue.Infos()
+			if len(infos) != len(tc.infos) {
+				t.Fatalf("infos = %q, want %q", infos, tc.infos)
+			}
+			for i := range infos {
+				if infos[i] != tc.infos[i] {
+					t.Errorf("infos[%d] = %q, want %q", i, infos[i], tc.infos[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSpellingWordFiles(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "words.txt"), []byte("kubectl\nhelmfile\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// relative word files are resolved against config file directory
+	r := &SpellingRule{}
+	setting := lint.RuleSetting{
+		Flags: map[string]interface{}{"word-files": []interface{}{"words.txt"}},
+		Dir:   dir,
+	}
+	applyRule(t, r, setting)
+
+	issue, isValid := r.Validate(parseCommit(t, "chore: run kubectl from helmfile"))
+	if !isValid {
+		t.Errorf("words from word file are misspelled: %v", issue.Infos())
+	}
+
+	setting.Dir = ""
+	err = (&SpellingRule{}).Apply(setting)
+	if err == nil {
+		t.Error("expected error for word file missing in working directory")
+	}
+}
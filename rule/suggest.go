@@ -0,0 +1,162 @@
+package rule
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxSuggestions is the max number of suggestions given for a word
+const maxSuggestions = 3
+
+// closestWords returns upto maxSuggestions candidates which are within
+// maxDist edits of word, closest first. Case insensitive match is distance 0
+func closestWords(word string, candidates []string, maxDist int) []string {
+	type match struct {
+		word string
+		dist int
+
+		// tie breakers for same distance
+		isAnagram bool
+		sameFirst bool
+		lenDiff   int
+	}
+
+	if word == "" {
+		return nil
+	}
+
+	lowerWord := strings.ToLower(word)
+	wordLen := len([]rune(lowerWord))
+
+	var matches []match
+	for _, c := range candidates {
+		// empty value can be allowed, like empty scope in scope-enum
+		if c == "" || c == word {
+			continue
+		}
+
+		lowerC := strings.ToLower(c)
+		if lowerC == lowerWord {
+			matches = append(matches, match{word: c})
+			continue
+		}
+
+		// skip candidates which cannot be within maxDist
+		lenDiff := abs(len([]rune(lowerC)) - wordLen)
+		if lenDiff > maxDist {
+			continue
+		}
+
+		dist := editDistance(lowerWord, lowerC)
+		if dist <= maxDist {
+			isAnagram := sortedRunes(lowerC) == sortedRunes(lowerWord)
+			sameFirst := lowerC[0] == lowerWord[0]
+			matches = append(matches, match{c, dist, isAnagram, sameFirst, lenDiff})
+		}
+	}
+
+	// closest first, typos usually swap letters, keep first letter and length
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.dist != b.dist {
+			return a.dist < b.dist
+		}
+		if a.isAnagram != b.isAnagram {
+			return a.isAnagram
+		}
+		if a.sameFirst != b.sameFirst {
+			return a.sameFirst
+		}
+		return a.lenDiff < b.lenDiff
+	})
+
+	if len(matches) > maxSuggestions {
+		matches = matches[:maxSuggestions]
+	}
+
+	suggestions := make([]string, len(matches))
+	for i, m := range matches {
+		suggestions[i] = m.word
+	}
+	return suggestions
+}
+
+// enumSuggestions returns the allowed values closest to given invalid value
+// short values allow only one edit to avoid unrelated suggestions
+func enumSuggestions(value string, allowed []string) []string {
+	if value == "" {
+		return nil
+	}
+
+	maxDist := 2
+	if len([]rune(value)) <= 3 {
+		maxDist = 1
+	}
+
+	suggestions := closestWords(value, allowed, maxDist)
+	if len(suggestions) == 0 {
+		return nil
+	}
+
+	// keep only the closest, 'feet' should suggest 'feat' and not 'test'
+	lowerValue := strings.ToLower(value)
+	bestDist := editDistance(lowerValue, strings.ToLower(suggestions[0]))
+	closest := suggestions[:1]
+	for _, s := range suggestions[1:] {
+		if editDistance(lowerValue, strings.ToLower(s)) == bestDist {
+			closest = append(closest, s)
+		}
+	}
+	return closest
+}
+
+// didYouMean returns 'did you mean 'a', 'b'?' for given suggestions
+func didYouMean(suggestions []string) string {
+	return fmt.Sprintf("did you mean %s?", quoteJoin(suggestions))
+}
+
+// editDistance returns the optimal string alignment distance between a and b
+// which counts insertion, deletion, substitution and transposition as one edit
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	// rows of the distance matrix, two previous rows needed for transposition
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(rb)]
+}
+
+func sortedRunes(s string) string {
+	r := []rune(s)
+	sort.Slice(r, func(i, j int) bool { return r[i] < r[j] })
+	return string(r)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
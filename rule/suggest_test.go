@@ -0,0 +1,45 @@
+package rule
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEnumSuggestions(t *testing.T) {
+	types := []string{"build", "chore", "ci", "docs", "feat", "fix", "perf", "refactor", "revert", "style", "test"}
+
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"feet", []string{"feat"}},
+		{"FEAT", []string{"feat"}},
+		{"fxi", []string{"fix"}},
+		{"refactr", []string{"refactor"}},
+		{"doc", []string{"docs"}},
+		{"ux", nil},
+		{"", nil},
+	}
+
+	for _, test := range tests {
+		got := enumSuggestions(test.value, types)
+		if len(got) == 0 && len(test.want) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%q: expected %v, got %v", test.value, test.want, got)
+		}
+	}
+}
+
+func TestClosestWordsEmptyCandidate(t *testing.T) {
+	scopes := []string{"", "a", "api"}
+
+	got := closestWords("b", scopes, 2)
+	if !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("expected [a], got %v", got)
+	}
+	if got := closestWords("", scopes, 2); got != nil {
+		t.Errorf("expected no suggestions for empty word, got %v", got)
+	}
+}
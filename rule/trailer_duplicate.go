@@ -0,0 +1,82 @@
+package rule
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zexot-com/commitlint/lint"
+)
+
+var (
+	_ lint.PartialRule = (*TrailerDuplicateRule)(nil)
+	_ lint.SchemaRule  = (*TrailerDuplicateRule)(nil)
+)
+
+// TrailerDuplicateRule to validate trailers are not repeated
+type TrailerDuplicateRule struct {
+	// Repeatable tokens can be repeated with different values
+	Repeatable []string
+}
+
+// Name return name of the rule
+func (r *TrailerDuplicateRule) Name() string { return "trailer-duplicate" }
+
+// CanValidatePartial returns true as duplicates are found in trailers only
+func (r *TrailerDuplicateRule) CanValidatePartial() bool { return true }
+
+// Apply sets the needed argument for the rule
+func (r *TrailerDuplicateRule) Apply(setting lint.RuleSetting) error {
+	err := setStringArrArg(&r.Repeatable, setting.Argument)
+	if err != nil {
+		return errInvalidArg(r.Name(), err)
+	}
+	return nil
+}
+
+// ArgumentSchema returns the schema of repeatable trailer tokens
+func (r *TrailerDuplicateRule) ArgumentSchema() lint.Schema { return stringArrSchema() }
+
+// FlagsSchema returns nil as rule has no flags
+func (r *TrailerDuplicateRule) FlagsSchema() map[string]lint.Schema { return nil }
+
+// Validate validates TrailerDuplicateRule
+func (r *TrailerDuplicateRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
+	var invalids []string
+
+	seenTokens := make(map[string]struct{})
+	seenTrailers := make(map[string]struct{})
+
+	for _, t := range lint.CommitTrailers(msg) {
+		trailerKey := t.Key() + "\n" + t.Value
+		if _, ok := seenTrailers[trailerKey]; ok {
+			invalids = append(invalids, fmt.Sprintf("line %d: '%s' is repeated with same value", t.Line, t.Token))
+			continue
+		}
+		seenTrailers[trailerKey] = struct{}{}
+
+		if _, ok := seenTokens[t.Key()]; ok && !r.isRepeatable(t) {
+			invalids = append(invalids, fmt.Sprintf("line %d: '%s' is repeated", t.Line, t.Token))
+			continue
+		}
+		seenTokens[t.Key()] = struct{}{}
+	}
+
+	if len(invalids) == 0 {
+		return nil, true
+	}
+
+	desc := "trailers should not be repeated"
+	if len(r.Repeatable) > 0 {
+		desc += fmt.Sprintf(", except [%s]", strings.Join(r.Repeatable, ", "))
+	}
+	return lint.NewIssue(desc, invalids...), false
+}
+
+func (r *TrailerDuplicateRule) isRepeatable(t lint.Trailer) bool {
+	for _, token := range r.Repeatable {
+		if lint.TrailerKey(token) == t.Key() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package rule
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zexot-com/commitlint/lint"
+)
+
+var (
+	_ lint.PartialRule = (*TrailerOrderRule)(nil)
+	_ lint.SchemaRule  = (*TrailerOrderRule)(nil)
+)
+
+// TrailerOrderRule to validate the order of trailers
+type TrailerOrderRule struct {
+	Tokens []string
+}
+
+// Name return name of the rule
+func (r *TrailerOrderRule) Name() string { return "trailer-order" }
+
+// CanValidatePartial returns true as order of trailers does not depend on header
+func (r *TrailerOrderRule) CanValidatePartial() bool { return true }
+
+// Apply sets the needed argument for the rule
+func (r *TrailerOrderRule) Apply(setting lint.RuleSetting) error {
+	err := setStringArrArg(&r.Tokens, setting.Argument)
+	if err != nil {
+		return errInvalidArg(r.Name(), err)
+	}
+	return nil
+}
+
+// ArgumentSchema returns the schema of trailer tokens in order
+func (r *TrailerOrderRule) ArgumentSchema() lint.Schema { return stringArrSchema() }
+
+// FlagsSchema returns nil as rule has no flags
+func (r *TrailerOrderRule) FlagsSchema() map[string]lint.Schema { return nil }
+
+// Validate validates TrailerOrderRule
+func (r *TrailerOrderRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
+	var invalids []string
+
+	// trailers with tokens not in the list can be anywhere
+	prevIndex := -1
+	var prevToken string
+	for _, t := range lint.CommitTrailers(msg) {
+		index := r.tokenIndex(t)
+		if index < 0 {
+			continue
+		}
+		if index < prevIndex {
+			invalids = append(invalids, fmt.Sprintf("line %d: '%s' should be before '%s'", t.Line, t.Token, prevToken))
+			continue
+		}
+		prevIndex = index
+		prevToken = t.Token
+	}
+
+	if len(invalids) == 0 {
+		return nil, true
+	}
+
+	desc := fmt.Sprintf("trailers should be in order [%s]", strings.Join(r.Tokens, ", "))
+	return lint.NewIssue(desc, invalids...), false
+}
+
+func (r *TrailerOrderRule) tokenIndex(t lint.Trailer) int {
+	for index, token := range r.Tokens {
+		if lint.TrailerKey(token) == t.Key() {
+			return index
+		}
+	}
+	return -1
+}
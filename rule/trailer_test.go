@@ -0,0 +1,170 @@
+package rule
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zexot-com/commitlint/lint"
+)
+
+type trailerTest struct {
+	name  string
+	msg   string
+	arg   interface{}
+	infos []string
+	fix   string
+}
+
+func runTrailerTests(t *testing.T, r lint.Rule, tests []trailerTest) {
+	t.Helper()
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			applyRule(t, r, lint.RuleSetting{Argument: tc.arg})
+
+			issue, isValid := r.Validate(parseCommit(t, tc.msg))
+			if isValid != (len(tc.infos) == 0) {
+				t.Fatalf("isValid = %v, issue %+v", isValid, issue)
+			}
+			if isValid {
+				return
+			}
+			if !reflect.DeepEqual(issue.Infos(), tc.infos) {
+				t.Errorf("infos = %q, want %q", issue.Infos(), tc.infos)
+			}
+			if issue.Fix() != tc.fix {
+				t.Errorf("fix = %q, want %q", issue.Fix(), tc.fix)
+			}
+		})
+	}
+}
+
+func TestTrailerValuePattern(t *testing.T) {
+	arg := []interface{}{
+		map[interface{}]interface{}{"token": "Refs", "pattern": `[A-Z]+-[0-9]+`},
+		map[interface{}]interface{}{"token": "BREAKING CHANGE", "pattern": `[a-z ]+`},
+	}
+
+	runTrailerTests(t, &TrailerValuePatternRule{}, []trailerTest{
+		{name: "valid", msg: "feat: add login\n\nRefs: PAY-12", arg: arg},
+		{name: "token case ignored", msg: "feat: add login\n\nrefs: PAY-12", arg: arg},
+		{name: "other token", msg: "feat: add login\n\nCloses: 12", arg: arg},
+		{
+			name:  "partial match",
+			msg:   "feat: add login\n\nRefs: PAY-12 and more",
+			arg:   arg,
+			infos: []string{"line 3: 'Refs' value 'PAY-12 and more' should match '[A-Z]+-[0-9]+'"},
+		},
+		{
+			name:  "breaking change alias",
+			msg:   "feat: add login\n\nBREAKING-CHANGE: API removed",
+			arg:   arg,
+			infos: []string{"line 3: 'BREAKING-CHANGE' value 'API removed' should match '[a-z ]+'"},
+		},
+	})
+
+	err := (&TrailerValuePatternRule{}).Apply(lint.RuleSetting{
+		Argument: []interface{}{map[interface{}]interface{}{"token": "Refs"}},
+	})
+	if err == nil {
+		t.Error("expected error for missing pattern")
+	}
+}
+
+func TestTrailerDuplicate(t *testing.T) {
+	arg := []interface{}{"Co-authored-by"}
+
+	runTrailerTests(t, &TrailerDuplicateRule{}, []trailerTest{
+		{name: "no duplicate", msg: "feat: add login\n\nRefs: PAY-1\nCloses: #2", arg: arg},
+		{name: "repeatable", msg: "feat: add login\n\nCo-authored-by: A <a@b.c>\nCo-authored-by: B <b@b.c>", arg: arg},
+		{
+			name:  "repeated token",
+			msg:   "feat: add login\n\nRefs: PAY-1\nrefs: PAY-2",
+			arg:   arg,
+			infos: []string{"line 4: 'refs' is repeated"},
+		},
+		{
+			name:  "repeated value",
+			msg:   "feat: add login\n\nCo-authored-by: A <a@b.c>\nCo-authored-by: A <a@b.c>",
+			arg:   arg,
+			infos: []string{"line 4: 'Co-authored-by' is repeated with same value"},
+		},
+		{
+			name:  "breaking change alias",
+			msg:   "feat: add login\n\nBREAKING CHANGE: a\nBREAKING-CHANGE: b",
+			arg:   arg,
+			infos: []string{"line 4: 'BREAKING-CHANGE' is repeated"},
+		},
+		{
+			name: "repeatable breaking change alias",
+			msg:  "feat: add login\n\nBREAKING CHANGE: a\nBREAKING-CHANGE: b",
+			arg:  []interface{}{"BREAKING CHANGE"},
+		},
+	})
+}
+
+func TestTrailerDuplicateDescription(t *testing.T) {
+	msg := "feat: add login\n\nRefs: PAY-1\nRefs: PAY-2"
+
+	tests := []struct {
+		name string
+		arg  []interface{}
+		desc string
+	}{
+		{"no repeatable", []interface{}{}, "trailers should not be repeated"},
+		{"repeatable", []interface{}{"Co-authored-by", "Acked-by"}, "trailers should not be repeated, except [Co-authored-by, Acked-by]"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &TrailerDuplicateRule{}
+			applyRule(t, r, lint.RuleSetting{Argument: tc.arg})
+
+			issue, isValid := r.Validate(parseCommit(t, msg))
+			if isValid {
+				t.Fatal("expected issue for repeated trailer")
+			}
+			if issue.Description() != tc.desc {
+				t.Errorf("description = %q, want %q", issue.Description(), tc.desc)
+			}
+		})
+	}
+}
+
+func TestTrailerTokenCase(t *testing.T) {
+	arg := []interface{}{"Signed-off-by", "BREAKING CHANGE"}
+
+	runTrailerTests(t, &TrailerTokenCaseRule{}, []trailerTest{
+		{name: "valid", msg: "feat: add login\n\nSigned-off-by: A <a@b.c>", arg: arg},
+		{name: "breaking change alias", msg: "feat: add login\n\nBREAKING-CHANGE: api removed", arg: arg},
+		{name: "unknown token", msg: "feat: add login\n\nrefs: PAY-1", arg: arg},
+		{
+			name:  "wrong case",
+			msg:   "feat: add login\n\nbody\n\nsigned-off-by: A <a@b.c>\nbreaking-change: api removed",
+			arg:   arg,
+			infos: []string{"line 5: 'signed-off-by' should be 'Signed-off-by'", "line 6: 'breaking-change' should be 'BREAKING CHANGE'"},
+			fix:   "feat: add login\n\nbody\n\nSigned-off-by: A <a@b.c>\nBREAKING CHANGE: api removed",
+		},
+	})
+}
+
+func TestTrailerOrder(t *testing.T) {
+	arg := []interface{}{"Refs", "Signed-off-by"}
+
+	runTrailerTests(t, &TrailerOrderRule{}, []trailerTest{
+		{name: "in order", msg: "feat: add login\n\nRefs: PAY-1\nSigned-off-by: A <a@b.c>", arg: arg},
+		{name: "other tokens anywhere", msg: "feat: add login\n\nCloses: #1\nRefs: PAY-1\nAcked-by: B\nSigned-off-by: A <a@b.c>", arg: arg},
+		{
+			name:  "out of order",
+			msg:   "feat: add login\n\nSigned-off-by: A <a@b.c>\nrefs: PAY-1",
+			arg:   arg,
+			infos: []string{"line 4: 'refs' should be before 'Signed-off-by'"},
+		},
+		{
+			name:  "breaking change alias",
+			msg:   "feat: add login\n\nBREAKING-CHANGE: api removed\nRefs: PAY-1",
+			arg:   []interface{}{"Refs", "BREAKING CHANGE"},
+			infos: []string{"line 4: 'Refs' should be before 'BREAKING-CHANGE'"},
+		},
+	})
+}
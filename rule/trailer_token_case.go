@@ -0,0 +1,77 @@
+package rule
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zexot-com/commitlint/lint"
+)
+
+var (
+	_ lint.PartialRule = (*TrailerTokenCaseRule)(nil)
+	_ lint.SchemaRule  = (*TrailerTokenCaseRule)(nil)
+)
+
+// TrailerTokenCaseRule to validate trailer tokens are written in canonical case
+type TrailerTokenCaseRule struct {
+	Tokens []string
+}
+
+// Name return name of the rule
+func (r *TrailerTokenCaseRule) Name() string { return "trailer-token-case" }
+
+// CanValidatePartial returns true as trailer tokens are parsed without header
+func (r *TrailerTokenCaseRule) CanValidatePartial() bool { return true }
+
+// Apply sets the needed argument for the rule
+func (r *TrailerTokenCaseRule) Apply(setting lint.RuleSetting) error {
+	err := setStringArrArg(&r.Tokens, setting.Argument)
+	if err != nil {
+		return errInvalidArg(r.Name(), err)
+	}
+	return nil
+}
+
+// ArgumentSchema returns the schema of trailer tokens
+func (r *TrailerTokenCaseRule) ArgumentSchema() lint.Schema { return stringArrSchema() }
+
+// FlagsSchema returns nil as rule has no flags
+func (r *TrailerTokenCaseRule) FlagsSchema() map[string]lint.Schema { return nil }
+
+// Validate validates TrailerTokenCaseRule
+func (r *TrailerTokenCaseRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
+	var invalids []string
+
+	fixedMsg := msg.Message()
+	for _, t := range lint.CommitTrailers(msg) {
+		canonical, ok := r.canonicalToken(t)
+		if !ok || canonical == t.Token {
+			continue
+		}
+
+		// BREAKING-CHANGE is an alias of BREAKING CHANGE
+		if t.IsBreakingChange() && t.Token == strings.ToUpper(t.Token) {
+			continue
+		}
+
+		invalids = append(invalids, fmt.Sprintf("line %d: '%s' should be '%s'", t.Line, t.Token, canonical))
+		fixedLine := canonical + strings.TrimPrefix(strings.SplitN(t.Raw, "\n", 2)[0], t.Token)
+		fixedMsg = replaceLine(fixedMsg, t.Line, fixedLine)
+	}
+
+	if len(invalids) == 0 {
+		return nil, true
+	}
+
+	desc := fmt.Sprintf("trailer tokens should be written as [%s]", strings.Join(r.Tokens, ", "))
+	return lint.NewIssue(desc, invalids...).WithFix(fixedMsg), false
+}
+
+func (r *TrailerTokenCaseRule) canonicalToken(t lint.Trailer) (string, bool) {
+	for _, token := range r.Tokens {
+		if lint.TrailerKey(token) == t.Key() {
+			return token, true
+		}
+	}
+	return "", false
+}
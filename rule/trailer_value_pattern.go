@@ -0,0 +1,118 @@
+package rule
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/zexot-com/commitlint/lint"
+)
+
+var (
+	_ lint.PartialRule = (*TrailerValuePatternRule)(nil)
+	_ lint.SchemaRule  = (*TrailerValuePatternRule)(nil)
+)
+
+// TrailerValuePatternRule to validate trailer values with regex per token
+type TrailerValuePatternRule struct {
+	Params []*TrailerValuePatternParam
+}
+
+// TrailerValuePatternParam represent a single token pattern param
+type TrailerValuePatternParam struct {
+	Token string
+
+	// Pattern matches the complete value, it is compiled from PatternStr
+	Pattern    *regexp.Regexp
+	PatternStr string
+}
+
+// Name return name of the rule
+func (r *TrailerValuePatternRule) Name() string { return "trailer-value-pattern" }
+
+// CanValidatePartial returns true as trailer values are read from footer
+func (r *TrailerValuePatternRule) CanValidatePartial() bool { return true }
+
+// Apply sets the needed argument for the rule
+func (r *TrailerValuePatternRule) Apply(setting lint.RuleSetting) error {
+	confParams, ok := setting.Argument.([]interface{})
+	if !ok {
+		return errInvalidArg(r.Name(), fmt.Errorf("expects array of params, but got %#v", setting.Argument))
+	}
+
+	params := make([]*TrailerValuePatternParam, 0, len(confParams))
+
+	for index, p := range confParams {
+		v, ok := p.(map[interface{}]interface{})
+		if !ok {
+			return errInvalidArg(r.Name()+": params", fmt.Errorf("expects key-value object, but got %#v", p))
+		}
+
+		tok, ok := v["token"]
+		if !ok {
+			return errMissingArg(r.Name(), "token in param "+strconv.Itoa(index+1))
+		}
+
+		pattern, ok := v["pattern"]
+		if !ok {
+			return errMissingArg(r.Name(), "pattern in param "+strconv.Itoa(index+1))
+		}
+
+		param := &TrailerValuePatternParam{}
+
+		err := setStringArg(&param.Token, tok)
+		if err != nil {
+			return errInvalidArg(r.Name()+": token", err)
+		}
+
+		param.PatternStr, err = toString(pattern)
+		if err != nil {
+			return errInvalidArg(r.Name()+": pattern", err)
+		}
+
+		// value should match the pattern completely
+		param.Pattern, err = regexp.Compile(`^(?:` + param.PatternStr + `)$`)
+		if err != nil {
+			return errInvalidArg(r.Name()+": pattern", err)
+		}
+
+		params = append(params, param)
+	}
+
+	r.Params = params
+	return nil
+}
+
+// ArgumentSchema returns the schema of token and pattern params
+func (r *TrailerValuePatternRule) ArgumentSchema() lint.Schema {
+	return paramsSchema(map[string]lint.Schema{
+		"token":   stringSchema(),
+		"pattern": stringSchema(),
+	})
+}
+
+// FlagsSchema returns nil as rule has no flags
+func (r *TrailerValuePatternRule) FlagsSchema() map[string]lint.Schema { return nil }
+
+// Validate validates TrailerValuePatternRule
+func (r *TrailerValuePatternRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
+	var invalids []string
+
+	for _, t := range lint.CommitTrailers(msg) {
+		for _, param := range r.Params {
+			if lint.TrailerKey(param.Token) != t.Key() {
+				continue
+			}
+			if !param.Pattern.MatchString(t.Value) {
+				invalids = append(invalids, fmt.Sprintf("line %d: '%s' value '%s' should match '%s'", t.Line, t.Token, t.Value, param.PatternStr))
+			}
+		}
+	}
+
+	if len(invalids) == 0 {
+		return nil, true
+	}
+
+	desc := "trailer value is invalid"
+	return lint.NewIssue(desc, invalids...), false
+}
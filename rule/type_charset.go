@@ -4,7 +4,7 @@ import (
 	"github.com/zexot-com/commitlint/lint"
 )
 
-var _ lint.Rule = (*TypeCharsetRule)(nil)
+var _ lint.SchemaRule = (*TypeCharsetRule)(nil)
 
 // TypeCharsetRule to validate max length of header
 type TypeCharsetRule struct {
@@ -23,6 +23,12 @@ func (r *TypeCharsetRule) Apply(setting lint.RuleSetting) error {
 	return nil
 }
 
+// ArgumentSchema returns the schema of allowed charset
+func (r *TypeCharsetRule) ArgumentSchema() lint.Schema { return stringSchema() }
+
+// FlagsSchema returns nil as rule has no flags
+func (r *TypeCharsetRule) FlagsSchema() map[string]lint.Schema { return nil }
+
 // Validate validates TypeCharsetRule
 func (r *TypeCharsetRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
 	invalidChars, isValid := validateCharset(r.Charset, msg.Type())
@@ -3,15 +3,23 @@ package rule
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/zexot-com/commitlint/lint"
 )
 
-var _ lint.Rule = (*TypeEnumRule)(nil)
+var (
+	_ lint.SchemaRule    = (*TypeEnumRule)(nil)
+	_ lint.TypeAliasRule = (*TypeEnumRule)(nil)
+)
 
 // TypeEnumRule to validate types
 type TypeEnumRule struct {
 	Types []string
+
+	// Aliases is lower cased alias to type, like 'feature' to 'feat'
+	Aliases       map[string]string
+	AcceptAliases bool
 }
 
 // Name return name of the rule
@@ -25,15 +33,102 @@ func (r *TypeEnumRule) Apply(setting lint.RuleSetting) error {
 	}
 	// sorting the string elements for binary search
 	sort.Strings(r.Types)
+
+	r.Aliases = make(map[string]string)
+	r.AcceptAliases = false
+
+	if aliases, ok := setting.Flags["aliases"]; ok {
+		err := r.setAliases(aliases)
+		if err != nil {
+			return errInvalidFlag(r.Name(), "aliases", err)
+		}
+	}
+
+	if acceptAliases, ok := setting.Flags["accept-aliases"]; ok {
+		err := setBoolArg(&r.AcceptAliases, acceptAliases)
+		if err != nil {
+			return errInvalidFlag(r.Name(), "accept-aliases", err)
+		}
+	}
 	return nil
 }
 
+func (r *TypeEnumRule) setAliases(aliases interface{}) error {
+	aliasMap, ok := aliases.(map[interface{}]interface{})
+	if !ok {
+		return fmt.Errorf("expected map, got %T", aliases)
+	}
+
+	for k, v := range aliasMap {
+		alias, err := toString(k)
+		if err != nil {
+			return err
+		}
+		typ, err := toString(v)
+		if err != nil {
+			return err
+		}
+		if !search(r.Types, typ) {
+			return fmt.Errorf("alias '%s' is for type '%s' which is not in %v", alias, typ, r.Types)
+		}
+		r.Aliases[strings.ToLower(alias)] = typ
+	}
+	return nil
+}
+
+// AliasType returns the type of alias, same as type-enum fixes it
+func (r *TypeEnumRule) AliasType(typ string) (string, bool) {
+	if search(r.Types, typ) {
+		return "", false
+	}
+	aliasType, ok := r.Aliases[strings.ToLower(typ)]
+	return aliasType, ok
+}
+
+// ArgumentSchema returns the schema of allowed types
+func (r *TypeEnumRule) ArgumentSchema() lint.Schema { return stringArrSchema() }
+
+// FlagsSchema returns the schema of rule flags
+func (r *TypeEnumRule) FlagsSchema() map[string]lint.Schema {
+	return map[string]lint.Schema{
+		"aliases":        stringMapSchema(),
+		"accept-aliases": boolSchema(),
+	}
+}
+
 // Validate validates TypeEnumRule
 func (r *TypeEnumRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
 	isFound := search(r.Types, msg.Type())
 	if isFound {
 		return nil, true
 	}
-	desc := fmt.Sprintf("type '%s' is not allowed, you can use one of %v", msg.Type(), r.Types)
-	return lint.NewIssue(desc), false
+
+	if typ, ok := r.Aliases[strings.ToLower(msg.Type())]; ok {
+		if r.AcceptAliases {
+			return nil, true
+		}
+		desc := fmt.Sprintf("type '%s' is an alias, use '%s' instead", msg.Type(), typ)
+		return lint.NewIssue(desc).WithSuggestions(typ).WithFix(r.fixType(msg, typ)), false
+	}
+
+	suggestions := enumSuggestions(msg.Type(), r.Types)
+	if len(suggestions) == 0 {
+		desc := fmt.Sprintf("type '%s' is not allowed, you can use one of %v", msg.Type(), r.Types)
+		return lint.NewIssue(desc), false
+	}
+
+	desc := fmt.Sprintf("type '%s' is not allowed, %s", msg.Type(), didYouMean(suggestions))
+	issue := lint.NewIssue(desc, fmt.Sprintf("you can use one of %v", r.Types)).WithSuggestions(suggestions...)
+
+	// only case mismatch is fixed, typos can have more than one suggestion
+	if strings.EqualFold(suggestions[0], msg.Type()) {
+		issue = issue.WithFix(r.fixType(msg, suggestions[0]))
+	}
+	return issue, false
+}
+
+// fixType replaces the type in header, emoji before header is skipped
+// as shortcode can contain the type like ':art:'
+func (r *TypeEnumRule) fixType(msg lint.Commit, typ string) string {
+	return replaceInHeader(msg, len(lint.CommitEmoji(msg)), msg.Type(), typ)
 }
@@ -2,11 +2,12 @@ package rule
 
 import "github.com/zexot-com/commitlint/lint"
 
-var _ lint.Rule = (*TypeMaxLenRule)(nil)
+var _ lint.SchemaRule = (*TypeMaxLenRule)(nil)
 
 // TypeMaxLenRule to validate max length of type
 type TypeMaxLenRule struct {
 	CheckLen int
+	Unit     LengthUnit
 }
 
 // Name return name of the rule
@@ -18,10 +19,16 @@ func (r *TypeMaxLenRule) Apply(setting lint.RuleSetting) error {
 	if err != nil {
 		return errInvalidArg(r.Name(), err)
 	}
-	return nil
+	return setUnitFlag(r.Name(), &r.Unit, setting.Flags)
 }
 
+// ArgumentSchema returns the schema of length argument
+func (r *TypeMaxLenRule) ArgumentSchema() lint.Schema { return intSchema() }
+
+// FlagsSchema returns the schema of unit flag
+func (r *TypeMaxLenRule) FlagsSchema() map[string]lint.Schema { return unitFlagSchema() }
+
 // Validate validates TypeMaxLenRule
 func (r *TypeMaxLenRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
-	return validateMaxLen("type", r.CheckLen, msg.Type())
+	return validateMaxLen("type", r.Unit, r.CheckLen, msg.Type())
 }
@@ -2,11 +2,12 @@ package rule
 
 import "github.com/zexot-com/commitlint/lint"
 
-var _ lint.Rule = (*TypeMinLenRule)(nil)
+var _ lint.SchemaRule = (*TypeMinLenRule)(nil)
 
 // TypeMinLenRule to validate min length of type
 type TypeMinLenRule struct {
 	CheckLen int
+	Unit     LengthUnit
 }
 
 // Name return name of the rule
@@ -18,10 +19,16 @@ func (r *TypeMinLenRule) Apply(setting lint.RuleSetting) error {
 	if err != nil {
 		return errInvalidArg(r.Name(), err)
 	}
-	return nil
+	return setUnitFlag(r.Name(), &r.Unit, setting.Flags)
 }
 
+// ArgumentSchema returns the schema of length argument
+func (r *TypeMinLenRule) ArgumentSchema() lint.Schema { return intSchema() }
+
+// FlagsSchema returns the schema of unit flag
+func (r *TypeMinLenRule) FlagsSchema() map[string]lint.Schema { return unitFlagSchema() }
+
 // Validate validates TypeMinLenRule
 func (r *TypeMinLenRule) Validate(msg lint.Commit) (*lint.Issue, bool) {
-	return validateMinLen("type", r.CheckLen, msg.Type())
+	return validateMinLen("type", r.Unit, r.CheckLen, msg.Type())
 }
@@ -0,0 +1,63 @@
+package rule
+
+import (
+	_ "embed" // for embedding word lists
+	"strings"
+	"sync"
+)
+
+// englishWords is the embedded english dictionary, one word per line
+// lines starting with '#' are comments, see data/genwords.go for source
+//
+//go:embed data/words.txt
+var englishWords string
+
+// verbForms is the embedded verb list, each line has a verb in imperative
+// form followed by its other forms
+//
+//go:embed data/verbs.txt
+var verbForms string
+
+var (
+	dictOnce sync.Once
+	dict     map[string]struct{}
+	dictList []string
+
+	verbsOnce  sync.Once
+	verbByForm map[string]string
+)
+
+// dictionary returns the embedded english words as set and sorted list
+func dictionary() (map[string]struct{}, []string) {
+	dictOnce.Do(func() {
+		dict = make(map[string]struct{})
+		for _, line := range strings.Split(englishWords, "\n") {
+			w := strings.TrimSpace(line)
+			if w == "" || strings.HasPrefix(w, "#") {
+				continue
+			}
+			dictList = append(dictList, w)
+			dict[w] = struct{}{}
+		}
+	})
+	return dict, dictList
+}
+
+// imperativeVerb returns the imperative form of given verb form
+// returns false if the word is not a known non-imperative verb form
+func imperativeVerb(word string) (string, bool) {
+	verbsOnce.Do(func() {
+		verbByForm = make(map[string]string)
+		for _, line := range strings.Split(verbForms, "\n") {
+			forms := strings.Fields(line)
+			if len(forms) < 2 {
+				continue
+			}
+			for _, form := range forms[1:] {
+				verbByForm[form] = forms[0]
+			}
+		}
+	})
+	verb, ok := verbByForm[strings.ToLower(word)]
+	return verb, ok
+}
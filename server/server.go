@@ -0,0 +1,332 @@
+// Package server provides http.Handler which lints commit messages
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/zexot-com/commitlint/config"
+	"github.com/zexot-com/commitlint/formatter"
+	"github.com/zexot-com/commitlint/internal"
+	"github.com/zexot-com/commitlint/internal/registry"
+	"github.com/zexot-com/commitlint/lint"
+)
+
+// maxRequestSize is the maximum size of request body
+const maxRequestSize = 1 << 20
+
+// maxCachedLinters limits linters cached for inline configs
+// cache is cleared when the limit is reached
+const maxCachedLinters = 128
+
+var (
+	errNoMessage    = errors.New("message or messages is required")
+	errBothMessages = errors.New("message cannot be used with messages")
+	errBothConfigs  = errors.New("config cannot be used with config_name")
+)
+
+// serverOnlyFlags are rule flags which read files or git repository of the
+// server, inline configs cannot enable them, named configs can
+var serverOnlyFlags = map[string][]string{
+	"spelling":            {"word-files"},
+	"references-required": {"branch-fallback"},
+}
+
+// LintRequest represent the body of lint request
+type LintRequest struct {
+	// Message is the commit message to lint
+	Message string `json:"message,omitempty"`
+
+	// Messages are the commit messages to lint, results are in same order
+	Messages []string `json:"messages,omitempty"`
+
+	// Config is inline config, a json object or yaml string in config file format
+	Config json.RawMessage `json:"config,omitempty"`
+
+	// ConfigName is the name of config added with WithConfig
+	ConfigName string `json:"config_name,omitempty"`
+}
+
+// RuleInfo represent a rule in rules response
+type RuleInfo struct {
+	Name     string        `json:"name"`
+	Enabled  bool          `json:"enabled"`
+	Severity lint.Severity `json:"severity,omitempty"`
+}
+
+// Handler lints commit messages posted as json, it is safe for concurrent use
+//
+//	POST /lint    lints LintRequest, returns json formatter output, an array for messages
+//	GET  /rules   lists rules, enabled rules of config given in 'config' query
+//	GET  /health  returns status and version
+type Handler struct {
+	mux *http.ServeMux
+
+	conf  *lint.Config
+	named map[string]*lint.Config
+
+	mut     sync.Mutex
+	linters map[string]*lint.Linter
+}
+
+// Option configures the Handler
+type Option func(h *Handler)
+
+// WithConfig adds a named config, which is selected by config_name in request
+func WithConfig(name string, conf *lint.Config) Option {
+	return func(h *Handler) {
+		h.named[name] = conf
+	}
+}
+
+// NewHandler returns a Handler which uses conf unless request has a config
+func NewHandler(conf *lint.Config, opts ...Option) *Handler {
+	h := &Handler{
+		mux:     http.NewServeMux(),
+		conf:    conf,
+		named:   make(map[string]*lint.Config),
+		linters: make(map[string]*lint.Linter),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	h.mux.HandleFunc("POST /lint", h.handleLint)
+	h.mux.HandleFunc("GET /rules", h.handleRules)
+	h.mux.HandleFunc("GET /health", h.handleHealth)
+	return h
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) handleLint(w http.ResponseWriter, r *http.Request) {
+	var req LintRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(&req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request: %w", err))
+		return
+	}
+
+	if req.Message != "" && len(req.Messages) > 0 {
+		writeError(w, http.StatusBadRequest, errBothMessages)
+		return
+	}
+	if req.Message == "" && len(req.Messages) == 0 {
+		writeError(w, http.StatusBadRequest, errNoMessage)
+		return
+	}
+
+	conf, status, err := h.requestConfig(req)
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+
+	linter, err := h.linter(conf)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	messages := req.Messages
+	if req.Message != "" {
+		messages = []string{req.Message}
+	}
+
+	results := make([]json.RawMessage, 0, len(messages))
+	for _, msg := range messages {
+		out, err := lintMessage(linter, msg)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		results = append(results, out)
+	}
+
+	if req.Message != "" {
+		writeJSON(w, http.StatusOK, results[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+func (h *Handler) handleRules(w http.ResponseWriter, r *http.Request) {
+	conf, status, err := h.requestConfig(LintRequest{ConfigName: r.URL.Query().Get("config")})
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+
+	enabled := make(map[string]bool, len(conf.Rules))
+	for _, name := range conf.Rules {
+		enabled[name] = true
+	}
+
+	allRules := registry.Rules()
+	rules := make([]RuleInfo, 0, len(allRules))
+	for _, r := range allRules {
+		info := RuleInfo{Name: r.Name(), Enabled: enabled[r.Name()]}
+		if info.Enabled {
+			info.Severity = conf.GetSeverity(r.Name())
+		}
+		rules = append(rules, info)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"rules": rules})
+}
+
+func (h *Handler) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok", "version": internal.Version()})
+}
+
+// requestConfig returns the inline or named config of request
+// or the default config, along with http status for error
+func (h *Handler) requestConfig(req LintRequest) (*lint.Config, int, error) {
+	if len(req.Config) > 0 && req.ConfigName != "" {
+		return nil, http.StatusBadRequest, errBothConfigs
+	}
+
+	if req.ConfigName != "" {
+		conf, ok := h.named[req.ConfigName]
+		if !ok {
+			return nil, http.StatusNotFound, fmt.Errorf("config '%s' not found", req.ConfigName)
+		}
+		return conf, 0, nil
+	}
+
+	if len(req.Config) == 0 {
+		return h.conf, 0, nil
+	}
+
+	confBytes := []byte(req.Config)
+	if bytes.HasPrefix(confBytes, []byte(`"`)) {
+		var yamlConf string
+		err := json.Unmarshal(confBytes, &yamlConf)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		confBytes = []byte(yamlConf)
+	}
+
+	conf, err := config.Decode(confBytes)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	if errs := config.Validate(conf); len(errs) > 0 {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid config: %w", errors.Join(errs...))
+	}
+
+	err = checkInlineConfig(conf)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	return conf, 0, nil
+}
+
+// checkInlineConfig returns error if inline conf enables serverOnlyFlags
+// flags with default values, like empty word-files, are allowed
+func checkInlineConfig(conf *lint.Config) error {
+	for ruleName, flags := range serverOnlyFlags {
+		setting, ok := conf.Settings[ruleName]
+		if !ok {
+			continue
+		}
+		for _, flag := range flags {
+			if isFlagSet(setting.Flags[flag]) {
+				return fmt.Errorf("invalid config: '%s' flag of '%s' is not allowed in inline config", flag, ruleName)
+			}
+		}
+	}
+	return nil
+}
+
+func isFlagSet(val interface{}) bool {
+	switch v := val.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case []interface{}:
+		return len(v) > 0
+	case []string:
+		return len(v) > 0
+	default:
+		return true
+	}
+}
+
+// linter returns the cached linter for conf, keyed by hash of conf
+func (h *Handler) linter(conf *lint.Config) (*lint.Linter, error) {
+	key, err := configHash(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	h.mut.Lock()
+	defer h.mut.Unlock()
+
+	if linter, ok := h.linters[key]; ok {
+		return linter, nil
+	}
+
+	linter, err := config.NewLinter(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(h.linters) >= maxCachedLinters {
+		h.linters = make(map[string]*lint.Linter)
+	}
+	h.linters[key] = linter
+	return linter, nil
+}
+
+func configHash(conf *lint.Config) (string, error) {
+	buf := &bytes.Buffer{}
+	err := config.WriteTo(buf, conf)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// lintMessage returns the lint result of msg in json formatter output
+func lintMessage(linter *lint.Linter, msg string) (json.RawMessage, error) {
+	result, err := linter.ParseAndLint(strings.TrimSpace(msg))
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := (&formatter.JSONFormatter{}).Format(result)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(out), nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,184 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/zexot-com/commitlint/config"
+	"github.com/zexot-com/commitlint/lint"
+)
+
+type lintOutput struct {
+	Input  string `json:"input"`
+	Issues []struct {
+		Name     string `json:"name"`
+		Severity string `json:"severity"`
+	} `json:"issues"`
+}
+
+func newTestHandler(t *testing.T) *Handler {
+	t.Helper()
+
+	strict, err := config.Decode([]byte(`
+version: v0.1.0
+rules: [type-enum]
+settings:
+  type-enum:
+    argument: [feat]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewHandler(config.NewDefault(), WithConfig("strict", strict))
+}
+
+func doRequest(h http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestLint(t *testing.T) {
+	h := newTestHandler(t)
+
+	rec := doRequest(h, http.MethodPost, "/lint", `{"message": "fix: correct typo in readme"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	var single lintOutput
+	err := json.Unmarshal(rec.Body.Bytes(), &single)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if single.Input != "fix: correct typo in readme" || len(single.Issues) != 0 {
+		t.Errorf("unexpected output %+v", single)
+	}
+
+	rec = doRequest(h, http.MethodPost, "/lint", `{"messages": ["feat: a", "fix: b"], "config_name": "strict"}`)
+	var multi []lintOutput
+	err = json.Unmarshal(rec.Body.Bytes(), &multi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(multi) != 2 || len(multi[0].Issues) != 0 || len(multi[1].Issues) != 1 || multi[1].Issues[0].Name != "type-enum" {
+		t.Errorf("unexpected output %+v", multi)
+	}
+}
+
+func TestLintInlineConfig(t *testing.T) {
+	h := newTestHandler(t)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		issues int
+	}{
+		{"json object", `{"message": "docs: x", "config": {"version": "v0.1.0", "rules": ["type-enum"], "settings": {"type-enum": {"argument": ["feat"]}}}}`, http.StatusOK, 1},
+		{"yaml string", `{"message": "docs: x", "config": "version: v0.1.0\nrules: [type-enum]\nsettings:\n  type-enum:\n    argument: [docs]\n"}`, http.StatusOK, 0},
+		{"invalid config", `{"message": "docs: x", "config": {"rules": ["unknown-rule"]}}`, http.StatusBadRequest, 0},
+		{"unknown config name", `{"message": "docs: x", "config_name": "missing"}`, http.StatusNotFound, 0},
+		{"no message", `{}`, http.StatusBadRequest, 0},
+		{"unknown field", `{"msg": "docs: x"}`, http.StatusBadRequest, 0},
+		{"word files", `{"message": "docs: x", "config": {"version": "v0.1.0", "rules": ["spelling"], "settings": {"spelling": {"flags": {"word-files": ["/etc/hostname"]}}}}}`, http.StatusBadRequest, 0},
+		{"empty word files", `{"message": "docs: fix the guide", "config": {"version": "v0.1.0", "rules": ["spelling"], "settings": {"spelling": {"flags": {"word-files": []}}}}}`, http.StatusOK, 0},
+		{"branch fallback", `{"message": "docs: x", "config": "version: v0.1.0\nrules: [references-required]\nsettings:\n  references-required:\n    flags:\n      branch-fallback: true\n"}`, http.StatusBadRequest, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := doRequest(h, http.MethodPost, "/lint", tc.body)
+			if rec.Code != tc.status {
+				t.Fatalf("status = %d, want %d, body %s", rec.Code, tc.status, rec.Body)
+			}
+			if tc.status != http.StatusOK {
+				return
+			}
+
+			var out lintOutput
+			err := json.Unmarshal(rec.Body.Bytes(), &out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(out.Issues) != tc.issues {
+				t.Errorf("got %d issues, want %d", len(out.Issues), tc.issues)
+			}
+		})
+	}
+}
+
+func TestLintConcurrent(t *testing.T) {
+	h := newTestHandler(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		allowed := "feat"
+		if i%2 == 0 {
+			allowed = "fix"
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			body := `{"message": "feat: x", "config": "version: v0.1.0\nrules: [type-enum]\nsettings:\n  type-enum:\n    argument: [` + allowed + `]\n"}`
+			rec := doRequest(h, http.MethodPost, "/lint", body)
+
+			var out lintOutput
+			err := json.Unmarshal(rec.Body.Bytes(), &out)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if wantIssue := allowed != "feat"; (len(out.Issues) == 1) != wantIssue {
+				t.Errorf("allowed %s: got %d issues", allowed, len(out.Issues))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(h.linters) != 2 {
+		t.Errorf("got %d cached linters, want 2", len(h.linters))
+	}
+}
+
+func TestRulesAndHealth(t *testing.T) {
+	h := newTestHandler(t)
+
+	rec := doRequest(h, http.MethodGet, "/rules?config=strict", "")
+	var out struct {
+		Rules []RuleInfo `json:"rules"`
+	}
+	err := json.Unmarshal(rec.Body.Bytes(), &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var enabled []string
+	for _, r := range out.Rules {
+		if r.Enabled {
+			enabled = append(enabled, r.Name)
+			if r.Severity != lint.SeverityError {
+				t.Errorf("%s severity = %s, want error", r.Name, r.Severity)
+			}
+		}
+	}
+	if len(enabled) != 1 || enabled[0] != "type-enum" {
+		t.Errorf("enabled rules = %v, want [type-enum]", enabled)
+	}
+
+	rec = doRequest(h, http.MethodGet, "/health", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"status":"ok"`) {
+		t.Errorf("unexpected health response %d %s", rec.Code, rec.Body)
+	}
+
+	rec = doRequest(h, http.MethodGet, "/lint", "")
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET /lint status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}